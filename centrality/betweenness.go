@@ -0,0 +1,261 @@
+package centrality
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// Edge identifies an edge by its source and target nodes; edges of an
+// undirected graph are identified with the lexicographically smaller node as the source
+type Edge struct {
+	Src n.Node
+	Tgt n.Node
+}
+
+// BetweennessConfig holds the settings for computing betweenness centrality
+type BetweennessConfig struct {
+	// Weighted uses edge weights as distances rather than counting each edge as distance one
+	Weighted bool
+	// Normalized scales scores by the number of pairs of nodes
+	Normalized bool
+	// Samples is the number of source nodes sampled to approximate betweenness, with zero using every node
+	Samples int
+	// Seed seeds the random selection of sampled source nodes
+	Seed int64
+}
+
+// Betweenness computes the betweenness centrality of each node of a graph using Brandes' algorithm
+func Betweenness(g graph.ReadOnlyGraph, cfg BetweennessConfig) (map[n.Node]float64, error) {
+	nodeBC, _, err := brandes(g, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes := float64(len(g.GetNodes()))
+	scale := 1.0
+	if cfg.Normalized {
+		// ordered pairs of nodes that do not include the node being scored
+		if numNodes > 2 {
+			scale = 1 / ((numNodes - 1) * (numNodes - 2))
+		}
+	} else if !g.Directed() {
+		// each undirected path has been counted once in each direction
+		scale = 0.5
+	}
+	scale *= sampleScale(numNodes, cfg)
+
+	for node := range nodeBC {
+		nodeBC[node] *= scale
+	}
+	return nodeBC, nil
+}
+
+// EdgeBetweenness computes the betweenness centrality of each edge of a graph using Brandes' algorithm
+func EdgeBetweenness(g graph.ReadOnlyGraph, cfg BetweennessConfig) (map[Edge]float64, error) {
+	_, edgeBC, err := brandes(g, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes := float64(len(g.GetNodes()))
+	scale := 1.0
+	if cfg.Normalized {
+		// ordered pairs of nodes
+		if numNodes > 1 {
+			scale = 1 / (numNodes * (numNodes - 1))
+		}
+	} else if !g.Directed() {
+		// each undirected path has been counted once in each direction
+		scale = 0.5
+	}
+	scale *= sampleScale(numNodes, cfg)
+
+	for e := range edgeBC {
+		edgeBC[e] *= scale
+	}
+	return edgeBC, nil
+}
+
+// sampleScale extrapolates scores accumulated from sampled source nodes to all source nodes
+func sampleScale(numNodes float64, cfg BetweennessConfig) float64 {
+	if cfg.Samples == 0 {
+		return 1
+	}
+	return numNodes / float64(cfg.Samples)
+}
+
+// edgeKey identifies an edge, ignoring direction for undirected graphs
+func edgeKey(src n.Node, tgt n.Node, directed bool) Edge {
+	if !directed && tgt < src {
+		return Edge{Src: tgt, Tgt: src}
+	}
+	return Edge{Src: src, Tgt: tgt}
+}
+
+// brandes accumulates the unscaled node and edge betweenness of a graph
+func brandes(g graph.ReadOnlyGraph, cfg BetweennessConfig) (map[n.Node]float64, map[Edge]float64, error) {
+	nodes := g.GetNodes()
+	directed := g.Directed()
+
+	nodeBC := make(map[n.Node]float64, len(nodes))
+	edgeBC := map[Edge]float64{}
+	for _, node := range nodes {
+		nodeBC[node] = 0
+		nbrs, _ := g.GetNeighbors(node)
+		for nbr := range nbrs {
+			edgeBC[edgeKey(node, nbr, directed)] = 0
+		}
+	}
+
+	sources, err := selectSources(nodes, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, src := range sources {
+		var sp *shortestPaths
+		if cfg.Weighted {
+			sp, err = weightedShortestPaths(g, src)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			sp = unweightedShortestPaths(g, src)
+		}
+
+		// accumulate dependencies in order of decreasing distance from the source
+		delta := map[n.Node]float64{}
+		for i := len(sp.order) - 1; i >= 0; i-- {
+			node := sp.order[i]
+			for _, pred := range sp.preds[node] {
+				c := sp.sigma[pred] / sp.sigma[node] * (1 + delta[node])
+				delta[pred] += c
+				edgeBC[edgeKey(pred, node, directed)] += c
+			}
+			if node != src {
+				nodeBC[node] += delta[node]
+			}
+		}
+	}
+
+	return nodeBC, edgeBC, nil
+}
+
+// selectSources returns the source nodes from which shortest paths are computed
+func selectSources(nodes []n.Node, cfg BetweennessConfig) ([]n.Node, error) {
+	if cfg.Samples < 0 || cfg.Samples > len(nodes) {
+		return nil, fmt.Errorf("number of samples %d must be between 0 and the number of nodes %d", cfg.Samples, len(nodes))
+	}
+	if cfg.Samples == 0 {
+		return nodes, nil
+	}
+
+	// sort before sampling so that a seed always selects the same nodes
+	sorted := make([]n.Node, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	sources := make([]n.Node, cfg.Samples)
+	for i, idx := range rnd.Perm(len(sorted))[:cfg.Samples] {
+		sources[i] = sorted[idx]
+	}
+	return sources, nil
+}
+
+// shortestPaths holds the shortest path structure from a single source node
+type shortestPaths struct {
+	// order holds the reachable nodes in order of nondecreasing distance from the source
+	order []n.Node
+	// preds holds the predecessors of each node on all of its shortest paths
+	preds map[n.Node][]n.Node
+	// sigma holds the number of shortest paths to each node
+	sigma map[n.Node]float64
+}
+
+func newShortestPaths(src n.Node) *shortestPaths {
+	return &shortestPaths{
+		order: []n.Node{},
+		preds: map[n.Node][]n.Node{},
+		sigma: map[n.Node]float64{src: 1},
+	}
+}
+
+// unweightedShortestPaths counts shortest paths from a source node using breadth first search
+func unweightedShortestPaths(g graph.ReadOnlyGraph, src n.Node) *shortestPaths {
+	sp := newShortestPaths(src)
+	dist := map[n.Node]int{src: 0}
+
+	q := n.NewQueue()
+	q.Push(src)
+	for q.Len() > 0 {
+		curNode, _ := q.Pop() // no need to check error since the queue cannot be empty here
+		sp.order = append(sp.order, curNode)
+
+		nbrs, ok := g.GetNeighbors(curNode)
+		if !ok {
+			continue
+		}
+		for nbr := range nbrs {
+			if _, ok := dist[nbr]; !ok {
+				dist[nbr] = dist[curNode] + 1
+				q.Push(nbr)
+			}
+			if dist[nbr] == dist[curNode]+1 {
+				sp.sigma[nbr] += sp.sigma[curNode]
+				sp.preds[nbr] = append(sp.preds[nbr], curNode)
+			}
+		}
+	}
+	return sp
+}
+
+// weightedShortestPaths counts shortest paths from a source node using Dijkstra's algorithm
+func weightedShortestPaths(g graph.ReadOnlyGraph, src n.Node) (*shortestPaths, error) {
+	sp := newShortestPaths(src)
+	dist := map[n.Node]float64{src: 0}
+	visited := n.NewSet()
+
+	pq := n.NewPriorityQueue()
+	pq.Push(src, 0)
+	for pq.Len() > 0 {
+		curNode, curDist, _ := pq.Pop() // no need to check error since the queue cannot be empty here
+		// nodes can be pushed more than once so skip those with an already finalized distance
+		if visited.Contains(curNode) {
+			continue
+		}
+		visited.Add(curNode)
+		sp.order = append(sp.order, curNode)
+
+		nbrs, ok := g.GetNeighbors(curNode)
+		if !ok {
+			continue
+		}
+		for nbr, wgt := range nbrs {
+			if wgt < 0 {
+				return nil, fmt.Errorf("negative weight %f on edge from %s to %s", wgt, curNode, nbr)
+			}
+			if visited.Contains(nbr) {
+				continue
+			}
+			nbrDist := curDist + wgt
+			d, ok := dist[nbr]
+			switch {
+			case !ok || nbrDist < d:
+				// a strictly shorter path replaces all previously found paths
+				dist[nbr] = nbrDist
+				sp.sigma[nbr] = sp.sigma[curNode]
+				sp.preds[nbr] = []n.Node{curNode}
+				pq.Push(nbr, nbrDist)
+			case nbrDist == d:
+				sp.sigma[nbr] += sp.sigma[curNode]
+				sp.preds[nbr] = append(sp.preds[nbr], curNode)
+			}
+		}
+	}
+	return sp, nil
+}
@@ -0,0 +1,259 @@
+package centrality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupPathGraph() *graph.Graph {
+	g, _ := graph.NewGraph("path")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+	return g
+}
+
+func setupPathDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("path")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+	return g
+}
+
+func setupSquareGraph() *graph.Graph {
+	g, _ := graph.NewGraph("square")
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("a", "d", 1)
+	g.AddEdge("d", "c", 2.5)
+	return g
+}
+
+func setupCycleGraph() *graph.Graph {
+	g, _ := graph.NewGraph("cycle")
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "c")
+	g.AddEdge("c", "d")
+	g.AddEdge("d", "e")
+	g.AddEdge("e", "a")
+	return g
+}
+
+func assertScores(t *testing.T, expected map[n.Node]float64, scores map[n.Node]float64) {
+	assert.Len(t, scores, len(expected))
+	for node, e := range expected {
+		assert.InDelta(t, e, scores[node], float64EqualTol)
+	}
+}
+
+func TestBetweenness(t *testing.T) {
+	tests := map[string]struct {
+		g              graph.ReadOnlyGraph
+		cfg            BetweennessConfig
+		expectedScores map[n.Node]float64
+	}{
+		"empty graph": {
+			g:              func() *graph.Graph { g, _ := graph.NewGraph("empty"); return g }(),
+			expectedScores: map[n.Node]float64{},
+		},
+		"undirected path": {
+			g:              setupPathGraph(),
+			expectedScores: map[n.Node]float64{"a": 0, "b": 2, "c": 2, "d": 0},
+		},
+		"undirected path normalized": {
+			g:              setupPathGraph(),
+			cfg:            BetweennessConfig{Normalized: true},
+			expectedScores: map[n.Node]float64{"a": 0, "b": 2.0 / 3, "c": 2.0 / 3, "d": 0},
+		},
+		"directed path": {
+			g:              setupPathDirGraph(),
+			expectedScores: map[n.Node]float64{"a": 0, "b": 2, "c": 2, "d": 0},
+		},
+		"directed path normalized": {
+			g:              setupPathDirGraph(),
+			cfg:            BetweennessConfig{Normalized: true},
+			expectedScores: map[n.Node]float64{"a": 0, "b": 1.0 / 3, "c": 1.0 / 3, "d": 0},
+		},
+		"unweighted square splits paths": {
+			g:              setupSquareGraph(),
+			expectedScores: map[n.Node]float64{"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5},
+		},
+		"weighted square": {
+			g:              setupSquareGraph(),
+			cfg:            BetweennessConfig{Weighted: true},
+			expectedScores: map[n.Node]float64{"a": 1, "b": 1, "c": 0, "d": 0},
+		},
+		"all nodes sampled": {
+			g:              setupPathGraph(),
+			cfg:            BetweennessConfig{Samples: 4},
+			expectedScores: map[n.Node]float64{"a": 0, "b": 2, "c": 2, "d": 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, err := Betweenness(test.g, test.cfg)
+			assert.Nil(t, err)
+			assertScores(t, test.expectedScores, scores)
+		})
+	}
+}
+
+func TestBetweennessSampled(t *testing.T) {
+	t.Run("sampled betweenness of a cycle preserves total betweenness", func(t *testing.T) {
+		g := setupCycleGraph()
+		cfg := BetweennessConfig{Samples: 2, Seed: 7}
+		scores, err := Betweenness(g, cfg)
+		assert.Nil(t, err)
+		// every node of a five cycle has betweenness one and each source contributes equally to the total
+		total := 0.0
+		for _, s := range scores {
+			total += s
+		}
+		assert.InDelta(t, 5, total, float64EqualTol)
+		// sampling with the same seed gives the same scores
+		again, _ := Betweenness(g, cfg)
+		assert.Equal(t, scores, again)
+	})
+}
+
+func TestBetweennessErrors(t *testing.T) {
+	tests := map[string]struct {
+		g   graph.ReadOnlyGraph
+		cfg BetweennessConfig
+	}{
+		"negative weight": {
+			g: func() *graph.Graph {
+				g := setupSquareGraph()
+				g.AddEdge("a", "c", -1)
+				return g
+			}(),
+			cfg: BetweennessConfig{Weighted: true},
+		},
+		"negative samples": {
+			g:   setupPathGraph(),
+			cfg: BetweennessConfig{Samples: -1},
+		},
+		"more samples than nodes": {
+			g:   setupPathGraph(),
+			cfg: BetweennessConfig{Samples: 5},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Betweenness(test.g, test.cfg)
+			assert.NotNil(t, err)
+			_, err = EdgeBetweenness(test.g, test.cfg)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestEdgeBetweenness(t *testing.T) {
+	tests := map[string]struct {
+		g              graph.ReadOnlyGraph
+		cfg            BetweennessConfig
+		expectedScores map[Edge]float64
+	}{
+		"empty graph": {
+			g:              func() *graph.Graph { g, _ := graph.NewGraph("empty"); return g }(),
+			expectedScores: map[Edge]float64{},
+		},
+		"undirected path": {
+			g: setupPathGraph(),
+			expectedScores: map[Edge]float64{
+				{Src: "a", Tgt: "b"}: 3,
+				{Src: "b", Tgt: "c"}: 4,
+				{Src: "c", Tgt: "d"}: 3,
+			},
+		},
+		"undirected path normalized": {
+			g:   setupPathGraph(),
+			cfg: BetweennessConfig{Normalized: true},
+			expectedScores: map[Edge]float64{
+				{Src: "a", Tgt: "b"}: 0.5,
+				{Src: "b", Tgt: "c"}: 2.0 / 3,
+				{Src: "c", Tgt: "d"}: 0.5,
+			},
+		},
+		"directed path": {
+			g: setupPathDirGraph(),
+			expectedScores: map[Edge]float64{
+				{Src: "a", Tgt: "b"}: 3,
+				{Src: "b", Tgt: "c"}: 4,
+				{Src: "c", Tgt: "d"}: 3,
+			},
+		},
+		"weighted square": {
+			g:   setupSquareGraph(),
+			cfg: BetweennessConfig{Weighted: true},
+			expectedScores: map[Edge]float64{
+				{Src: "a", Tgt: "b"}: 3,
+				{Src: "b", Tgt: "c"}: 2,
+				{Src: "a", Tgt: "d"}: 2,
+				{Src: "c", Tgt: "d"}: 1,
+			},
+		},
+		"self loop has no betweenness": {
+			g: func() *graph.Graph {
+				g := setupPathGraph()
+				g.AddEdge("b", "b")
+				return g
+			}(),
+			expectedScores: map[Edge]float64{
+				{Src: "a", Tgt: "b"}: 3,
+				{Src: "b", Tgt: "b"}: 0,
+				{Src: "b", Tgt: "c"}: 4,
+				{Src: "c", Tgt: "d"}: 3,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, err := EdgeBetweenness(test.g, test.cfg)
+			assert.Nil(t, err)
+			assert.Len(t, scores, len(test.expectedScores))
+			for e, expected := range test.expectedScores {
+				assert.InDelta(t, expected, scores[e], float64EqualTol)
+			}
+		})
+	}
+}
+
+func TestSelectSources(t *testing.T) {
+	t.Run("sampling is deterministic for a seed", func(t *testing.T) {
+		nodes := []n.Node{"d", "a", "c", "b", "e"}
+		cfg := BetweennessConfig{Samples: 3, Seed: 11}
+		sources, err := selectSources(nodes, cfg)
+		assert.Nil(t, err)
+		assert.Len(t, sources, 3)
+		reversed := []n.Node{"e", "b", "c", "a", "d"}
+		again, _ := selectSources(reversed, cfg)
+		assert.Equal(t, sources, again)
+	})
+}
+
+func TestBetweennessConcurrentGraph(t *testing.T) {
+	// directedness is determined by the graph rather than by its concrete type
+	dg := setupPathDirGraph()
+	cdg, _ := graph.NewConcurrentDirGraph("path")
+	for _, src := range dg.GetNodes() {
+		nbrs, _ := dg.GetNeighbors(src)
+		for tgt, wgt := range nbrs {
+			cdg.AddEdge(src, tgt, wgt)
+		}
+	}
+
+	expected, err := Betweenness(dg, BetweennessConfig{})
+	assert.Nil(t, err)
+	scores, err := Betweenness(cdg, BetweennessConfig{})
+	assert.Nil(t, err)
+	assertScores(t, expected, scores)
+}
@@ -0,0 +1,88 @@
+package centrality
+
+import (
+	"math"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+	"github.com/dkaslovsky/GoGraph/search"
+)
+
+// Closeness computes the closeness centrality of each node of a graph from the distances of
+// outgoing shortest paths, optionally using edge weights as distances; for a graph that is not
+// strongly connected a node's score is scaled by the fraction of other nodes it can reach; a node that
+// reaches other nodes only at zero distance over zero weight edges has infinite closeness
+func Closeness(g graph.ReadOnlyGraph, weighted bool) (map[n.Node]float64, error) {
+	nodes := g.GetNodes()
+	scores := make(map[n.Node]float64, len(nodes))
+
+	for _, node := range nodes {
+		dist, err := distances(g, node, weighted)
+		if err != nil {
+			return nil, err
+		}
+
+		total := 0.0
+		for _, d := range dist {
+			total += d
+		}
+		reached := float64(len(dist) - 1)
+		if reached == 0 || len(nodes) < 2 {
+			scores[node] = 0
+			continue
+		}
+		if total == 0 {
+			scores[node] = math.Inf(1)
+			continue
+		}
+		// the Wasserman and Faust scaling accounts for nodes that cannot be reached
+		scores[node] = (reached / total) * (reached / float64(len(nodes)-1))
+	}
+
+	return scores, nil
+}
+
+// Harmonic computes the harmonic centrality of each node of a graph, which is the sum of the reciprocal
+// distances of outgoing shortest paths to every other node, optionally using edge weights as distances;
+// unreachable nodes have infinite distance and so do not contribute, while a node reached at zero distance
+// over zero weight edges contributes an infinite reciprocal distance
+func Harmonic(g graph.ReadOnlyGraph, weighted bool) (map[n.Node]float64, error) {
+	nodes := g.GetNodes()
+	scores := make(map[n.Node]float64, len(nodes))
+
+	for _, node := range nodes {
+		dist, err := distances(g, node, weighted)
+		if err != nil {
+			return nil, err
+		}
+
+		scores[node] = 0
+		for nbr, d := range dist {
+			if nbr == node {
+				continue
+			}
+			if d == 0 {
+				scores[node] = math.Inf(1)
+				break
+			}
+			scores[node] += 1 / d
+		}
+	}
+
+	return scores, nil
+}
+
+// distances computes the shortest path distance from a node to every node reachable from it
+func distances(g graph.ReadOnlyGraph, node n.Node, weighted bool) (map[n.Node]float64, error) {
+	if weighted {
+		dist, _, err := search.Dijkstra(g, node)
+		return dist, err
+	}
+
+	depth := search.BFSTraversal(g, node).Depth
+	dist := make(map[n.Node]float64, len(depth))
+	for nbr, d := range depth {
+		dist[nbr] = float64(d)
+	}
+	return dist, nil
+}
@@ -0,0 +1,132 @@
+package centrality
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupDisconnectedGraph() *graph.Graph {
+	g, _ := graph.NewGraph("disconnected")
+	g.AddEdge("a", "b")
+	g.AddEdge("c", "d")
+	return g
+}
+
+func TestCloseness(t *testing.T) {
+	tests := map[string]struct {
+		g              graph.ReadOnlyGraph
+		weighted       bool
+		expectedScores map[n.Node]float64
+	}{
+		"empty graph": {
+			g:              func() *graph.Graph { g, _ := graph.NewGraph("empty"); return g }(),
+			expectedScores: map[n.Node]float64{},
+		},
+		"undirected path": {
+			g:              setupPathGraph(),
+			expectedScores: map[n.Node]float64{"a": 0.5, "b": 0.75, "c": 0.75, "d": 0.5},
+		},
+		"directed path": {
+			g:              setupPathDirGraph(),
+			expectedScores: map[n.Node]float64{"a": 0.5, "b": 4.0 / 9, "c": 1.0 / 3, "d": 0},
+		},
+		"disconnected graph": {
+			g:              setupDisconnectedGraph(),
+			expectedScores: map[n.Node]float64{"a": 1.0 / 3, "b": 1.0 / 3, "c": 1.0 / 3, "d": 1.0 / 3},
+		},
+		"unweighted square": {
+			g:              setupSquareGraph(),
+			expectedScores: map[n.Node]float64{"a": 0.75, "b": 0.75, "c": 0.75, "d": 0.75},
+		},
+		"weighted square": {
+			g:              setupSquareGraph(),
+			weighted:       true,
+			expectedScores: map[n.Node]float64{"a": 0.75, "b": 0.75, "c": 3 / 5.5, "d": 3 / 5.5},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, err := Closeness(test.g, test.weighted)
+			assert.Nil(t, err)
+			assertScores(t, test.expectedScores, scores)
+		})
+	}
+}
+
+func TestHarmonic(t *testing.T) {
+	tests := map[string]struct {
+		g              graph.ReadOnlyGraph
+		weighted       bool
+		expectedScores map[n.Node]float64
+	}{
+		"empty graph": {
+			g:              func() *graph.Graph { g, _ := graph.NewGraph("empty"); return g }(),
+			expectedScores: map[n.Node]float64{},
+		},
+		"undirected path": {
+			g:              setupPathGraph(),
+			expectedScores: map[n.Node]float64{"a": 11.0 / 6, "b": 2.5, "c": 2.5, "d": 11.0 / 6},
+		},
+		"directed path": {
+			g:              setupPathDirGraph(),
+			expectedScores: map[n.Node]float64{"a": 11.0 / 6, "b": 1.5, "c": 1, "d": 0},
+		},
+		"disconnected graph": {
+			g:              setupDisconnectedGraph(),
+			expectedScores: map[n.Node]float64{"a": 1, "b": 1, "c": 1, "d": 1},
+		},
+		"weighted square": {
+			g:              setupSquareGraph(),
+			weighted:       true,
+			expectedScores: map[n.Node]float64{"a": 2.5, "b": 2.5, "c": 1.5 + 1/2.5, "d": 1.5 + 1/2.5},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, err := Harmonic(test.g, test.weighted)
+			assert.Nil(t, err)
+			assertScores(t, test.expectedScores, scores)
+		})
+	}
+}
+
+func TestClosenessNegativeWeight(t *testing.T) {
+	t.Run("negative weight should error when weighted", func(t *testing.T) {
+		g := setupSquareGraph()
+		g.AddEdge("a", "c", -1)
+		_, err := Closeness(g, true)
+		assert.NotNil(t, err)
+		_, err = Harmonic(g, true)
+		assert.NotNil(t, err)
+		_, err = Closeness(g, false)
+		assert.Nil(t, err)
+	})
+}
+
+func TestClosenessZeroWeight(t *testing.T) {
+	g, _ := graph.NewDirGraph("test")
+	g.AddEdge("a", "b", 0)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("c", "d", 0)
+
+	scores, err := Closeness(g, true)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.75, scores["a"], float64EqualTol)
+	assert.InDelta(t, 1.0/3, scores["b"], float64EqualTol)
+	assert.True(t, math.IsInf(scores["c"], 1))
+	assert.Equal(t, 0.0, scores["d"])
+
+	scores, err = Harmonic(g, true)
+	assert.Nil(t, err)
+	assert.True(t, math.IsInf(scores["a"], 1))
+	assert.InDelta(t, 1.0, scores["b"], float64EqualTol)
+	assert.True(t, math.IsInf(scores["c"], 1))
+	assert.Equal(t, 0.0, scores["d"])
+}
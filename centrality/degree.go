@@ -0,0 +1,36 @@
+package centrality
+
+import (
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// InDegree computes the in degree of each node of a graph normalized by the number of other nodes
+func InDegree(g graph.WeightedGraph) map[n.Node]float64 {
+	return normalizedDegree(g, g.GetInDegree)
+}
+
+// OutDegree computes the out degree of each node of a graph normalized by the number of other nodes
+func OutDegree(g graph.WeightedGraph) map[n.Node]float64 {
+	return normalizedDegree(g, g.GetOutDegree)
+}
+
+// TotalDegree computes the total degree of each node of a graph normalized by the number of other nodes
+func TotalDegree(g graph.WeightedGraph) map[n.Node]float64 {
+	return normalizedDegree(g, g.GetTotalDegree)
+}
+
+func normalizedDegree(g graph.WeightedGraph, getDegree func(n.Node) (float64, bool)) map[n.Node]float64 {
+	nodes := g.GetNodes()
+	scores := make(map[n.Node]float64, len(nodes))
+
+	scale := 1.0
+	if len(nodes) > 1 {
+		scale = 1 / float64(len(nodes)-1)
+	}
+	for _, node := range nodes {
+		deg, _ := getDegree(node) // a node with no edges in the relevant direction has degree zero
+		scores[node] = deg * scale
+	}
+	return scores
+}
@@ -0,0 +1,59 @@
+package centrality
+
+import (
+	"testing"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupDegreeDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("degree")
+	g.AddEdge("a", "b", 2)
+	g.AddEdge("b", "c", 3)
+	g.AddEdge("c", "a", 1)
+	g.AddEdge("a", "c", 4)
+	return g
+}
+
+func TestDegree(t *testing.T) {
+	tests := map[string]struct {
+		g           graph.WeightedGraph
+		expectedIn  map[n.Node]float64
+		expectedOut map[n.Node]float64
+		expectedTot map[n.Node]float64
+	}{
+		"empty graph": {
+			g:           func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+			expectedIn:  map[n.Node]float64{},
+			expectedOut: map[n.Node]float64{},
+			expectedTot: map[n.Node]float64{},
+		},
+		"undirected graph": {
+			g:           setupPathGraph(),
+			expectedIn:  map[n.Node]float64{"a": 1.0 / 3, "b": 2.0 / 3, "c": 2.0 / 3, "d": 1.0 / 3},
+			expectedOut: map[n.Node]float64{"a": 1.0 / 3, "b": 2.0 / 3, "c": 2.0 / 3, "d": 1.0 / 3},
+			expectedTot: map[n.Node]float64{"a": 1.0 / 3, "b": 2.0 / 3, "c": 2.0 / 3, "d": 1.0 / 3},
+		},
+		"directed graph": {
+			g:           setupDegreeDirGraph(),
+			expectedIn:  map[n.Node]float64{"a": 0.5, "b": 1, "c": 3.5},
+			expectedOut: map[n.Node]float64{"a": 3, "b": 1.5, "c": 0.5},
+			expectedTot: map[n.Node]float64{"a": 3.5, "b": 2.5, "c": 4},
+		},
+		"directed graph with source and sink": {
+			g:           setupPathDirGraph(),
+			expectedIn:  map[n.Node]float64{"a": 0, "b": 1.0 / 3, "c": 1.0 / 3, "d": 1.0 / 3},
+			expectedOut: map[n.Node]float64{"a": 1.0 / 3, "b": 1.0 / 3, "c": 1.0 / 3, "d": 0},
+			expectedTot: map[n.Node]float64{"a": 1.0 / 3, "b": 2.0 / 3, "c": 2.0 / 3, "d": 1.0 / 3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertScores(t, test.expectedIn, InDegree(test.g))
+			assertScores(t, test.expectedOut, OutDegree(test.g))
+			assertScores(t, test.expectedTot, TotalDegree(test.g))
+		})
+	}
+}
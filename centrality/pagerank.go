@@ -0,0 +1,168 @@
+package centrality
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// PageRankConfig holds the settings for computing PageRank
+type PageRankConfig struct {
+	// Damping is the probability of following an edge rather than teleporting
+	Damping float64
+	// Tol is the per-node tolerance used to determine convergence
+	Tol float64
+	// MaxIter is the maximum number of power iterations
+	MaxIter int
+}
+
+// DefaultPageRankConfig returns the commonly used PageRank settings
+func DefaultPageRankConfig() PageRankConfig {
+	return PageRankConfig{
+		Damping: 0.85,
+		Tol:     1e-6,
+		MaxIter: 100,
+	}
+}
+
+func (cfg PageRankConfig) validate() error {
+	if cfg.Damping < 0 || cfg.Damping > 1 {
+		return fmt.Errorf("damping factor %f must be between 0 and 1", cfg.Damping)
+	}
+	if cfg.Tol <= 0 {
+		return fmt.Errorf("tolerance %f must be positive", cfg.Tol)
+	}
+	if cfg.MaxIter <= 0 {
+		return fmt.Errorf("maximum iterations %d must be positive", cfg.MaxIter)
+	}
+	return nil
+}
+
+// Convergence describes the outcome of an iterative computation
+type Convergence struct {
+	Iterations int
+	Converged  bool
+	// Delta is the L1 change in scores during the final iteration
+	Delta float64
+}
+
+// PageRank computes the weighted PageRank of each node of a graph, where a node with no outgoing edges
+// (a dangling node) distributes its score uniformly across all nodes; edge weights must not be negative
+func PageRank(g graph.WeightedGraph, cfg PageRankConfig) (map[n.Node]float64, Convergence, error) {
+	nodes := g.GetNodes()
+	teleport := make(map[n.Node]float64, len(nodes))
+	for _, node := range nodes {
+		teleport[node] = 1 / float64(len(nodes))
+	}
+	return pageRank(g, nodes, cfg, teleport)
+}
+
+// PersonalizedPageRank computes the weighted PageRank of each node of a graph using a teleport distribution,
+// normalized to sum to one, in place of teleporting uniformly; a node with no outgoing edges (a dangling node)
+// also distributes its score according to the teleport distribution
+func PersonalizedPageRank(
+	g graph.WeightedGraph,
+	cfg PageRankConfig,
+	teleport map[n.Node]float64,
+) (map[n.Node]float64, Convergence, error) {
+	nodes := g.GetNodes()
+	nodeSet := n.NewSet()
+	for _, node := range nodes {
+		nodeSet.Add(node)
+	}
+
+	sum := 0.0
+	for node, p := range teleport {
+		if !nodeSet.Contains(node) {
+			return nil, Convergence{}, fmt.Errorf("teleport node %s is not in the graph", node)
+		}
+		if p < 0 {
+			return nil, Convergence{}, fmt.Errorf("teleport probability %f of node %s is negative", p, node)
+		}
+		sum += p
+	}
+	if sum == 0 {
+		return nil, Convergence{}, errors.New("teleport distribution must have a positive sum")
+	}
+
+	normalized := make(map[n.Node]float64, len(teleport))
+	for node, p := range teleport {
+		normalized[node] = p / sum
+	}
+	return pageRank(g, nodes, cfg, normalized)
+}
+
+func pageRank(
+	g graph.WeightedGraph,
+	nodes []n.Node,
+	cfg PageRankConfig,
+	teleport map[n.Node]float64,
+) (map[n.Node]float64, Convergence, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, Convergence{}, err
+	}
+
+	scores := make(map[n.Node]float64, len(nodes))
+	if len(nodes) == 0 {
+		return scores, Convergence{Converged: true}, nil
+	}
+	for _, node := range nodes {
+		scores[node] = 1 / float64(len(nodes))
+	}
+
+	outDeg := make(map[n.Node]float64, len(nodes))
+	dangling := []n.Node{}
+	for _, node := range nodes {
+		// a negative weight would make scores negative or cancel out the weights of other edges
+		nbrs, _ := g.GetNeighbors(node)
+		for nbr, wgt := range nbrs {
+			if wgt < 0 {
+				return nil, Convergence{}, fmt.Errorf("negative weight %f on edge from %s to %s", wgt, node, nbr)
+			}
+		}
+		deg, _ := g.GetOutDegree(node)
+		if deg == 0 {
+			dangling = append(dangling, node)
+			continue
+		}
+		outDeg[node] = deg
+	}
+
+	conv := Convergence{}
+	for conv.Iterations < cfg.MaxIter {
+		conv.Iterations++
+
+		prev := scores
+		scores = make(map[n.Node]float64, len(nodes))
+
+		// score held by dangling nodes is redistributed by the teleport distribution
+		danglingSum := 0.0
+		for _, node := range dangling {
+			danglingSum += prev[node]
+		}
+		for node, p := range teleport {
+			scores[node] = (cfg.Damping*danglingSum + 1 - cfg.Damping) * p
+		}
+
+		for node, deg := range outDeg {
+			nbrs, _ := g.GetNeighbors(node)
+			for nbr, wgt := range nbrs {
+				scores[nbr] += cfg.Damping * prev[node] * wgt / deg
+			}
+		}
+
+		conv.Delta = 0
+		for _, node := range nodes {
+			conv.Delta += math.Abs(scores[node] - prev[node])
+		}
+		if conv.Delta < float64(len(nodes))*cfg.Tol {
+			conv.Converged = true
+			break
+		}
+	}
+
+	return scores, conv, nil
+}
@@ -0,0 +1,197 @@
+package centrality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// float64EqualTol is the tolerance at which we consider float64s equal
+const float64EqualTol = 1e-9
+
+// pageRankTol is the tolerance at which we consider converged scores equal
+const pageRankTol = 1e-5
+
+func setupDanglingDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("dangling")
+	g.AddEdge("a", "b")
+	return g
+}
+
+func setupCycleDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("cycle")
+	g.AddEdge("a", "b", 2)
+	g.AddEdge("b", "c", 3)
+	g.AddEdge("c", "a", 4)
+	return g
+}
+
+func setupWeightedDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("weighted")
+	g.AddEdge("a", "b", 3)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "a")
+	g.AddEdge("c", "a")
+	return g
+}
+
+func TestPageRank(t *testing.T) {
+	tests := map[string]struct {
+		g              *graph.DirGraph
+		expectedScores map[n.Node]float64
+	}{
+		"empty graph": {
+			g:              func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+			expectedScores: map[n.Node]float64{},
+		},
+		"cycle has uniform scores regardless of weights": {
+			g:              setupCycleDirGraph(),
+			expectedScores: map[n.Node]float64{"a": 1.0 / 3, "b": 1.0 / 3, "c": 1.0 / 3},
+		},
+		"dangling node distributes score uniformly": {
+			g: setupDanglingDirGraph(),
+			// solving a = 0.85*b/2 + 0.15/2 with a + b = 1
+			expectedScores: map[n.Node]float64{"a": 0.5 / 1.425, "b": 1 - 0.5/1.425},
+		},
+		"weighted edges": {
+			g: setupWeightedDirGraph(),
+			// solving a = 0.85*(b + c) + 0.05, b = 0.85*3a/4 + 0.05 and c = 0.85*a/4 + 0.05
+			expectedScores: map[n.Node]float64{
+				"a": 0.9 / 1.85,
+				"b": 0.6375*0.9/1.85 + 0.05,
+				"c": 0.2125*0.9/1.85 + 0.05,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, conv, err := PageRank(test.g, DefaultPageRankConfig())
+			assert.Nil(t, err)
+			assert.True(t, conv.Converged)
+			assert.Len(t, scores, len(test.expectedScores))
+			for node, expected := range test.expectedScores {
+				assert.InDelta(t, expected, scores[node], pageRankTol)
+			}
+		})
+	}
+}
+
+func TestPageRankUndirectedGraph(t *testing.T) {
+	t.Run("scores are proportional to degree", func(t *testing.T) {
+		g, _ := graph.NewGraph("triangle with tail")
+		g.AddEdge("a", "b")
+		g.AddEdge("b", "c")
+		g.AddEdge("c", "a")
+		g.AddEdge("a", "d")
+		cfg := DefaultPageRankConfig()
+		cfg.Damping = 1
+		scores, conv, err := PageRank(g, cfg)
+		assert.Nil(t, err)
+		assert.True(t, conv.Converged)
+		expectedScores := map[n.Node]float64{"a": 3.0 / 8, "b": 2.0 / 8, "c": 2.0 / 8, "d": 1.0 / 8}
+		for node, expected := range expectedScores {
+			assert.InDelta(t, expected, scores[node], pageRankTol)
+		}
+	})
+}
+
+func TestPageRankConvergence(t *testing.T) {
+	t.Run("maximum iterations reached before convergence", func(t *testing.T) {
+		cfg := DefaultPageRankConfig()
+		cfg.MaxIter = 2
+		scores, conv, err := PageRank(setupWeightedDirGraph(), cfg)
+		assert.Nil(t, err)
+		assert.False(t, conv.Converged)
+		assert.Equal(t, 2, conv.Iterations)
+		assert.Greater(t, conv.Delta, 0.0)
+		sum := 0.0
+		for _, s := range scores {
+			sum += s
+		}
+		assert.InEpsilon(t, 1, sum, float64EqualTol)
+	})
+}
+
+func TestPageRankInvalidConfig(t *testing.T) {
+	tests := map[string]PageRankConfig{
+		"negative damping":         {Damping: -0.1, Tol: 1e-6, MaxIter: 100},
+		"damping greater than one": {Damping: 1.1, Tol: 1e-6, MaxIter: 100},
+		"nonpositive tolerance":    {Damping: 0.85, Tol: 0, MaxIter: 100},
+		"nonpositive iterations":   {Damping: 0.85, Tol: 1e-6, MaxIter: 0},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := PageRank(setupCycleDirGraph(), cfg)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestPageRankNegativeWeight(t *testing.T) {
+	// the weights of a cancel out so it would otherwise be treated as a dangling node
+	g, _ := graph.NewDirGraph("negative")
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", -1)
+	g.AddEdge("b", "a", 1)
+
+	_, _, err := PageRank(g, DefaultPageRankConfig())
+	assert.NotNil(t, err)
+	_, _, err = PersonalizedPageRank(g, DefaultPageRankConfig(), map[n.Node]float64{"a": 1})
+	assert.NotNil(t, err)
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	tests := map[string]struct {
+		g              *graph.DirGraph
+		teleport       map[n.Node]float64
+		expectedScores map[n.Node]float64
+		shouldErr      bool
+	}{
+		"uniform teleport equals PageRank": {
+			g:              setupDanglingDirGraph(),
+			teleport:       map[n.Node]float64{"a": 3, "b": 3},
+			expectedScores: map[n.Node]float64{"a": 0.5 / 1.425, "b": 1 - 0.5/1.425},
+		},
+		"teleport to single node": {
+			g:        setupDanglingDirGraph(),
+			teleport: map[n.Node]float64{"a": 1},
+			// solving a = 0.85*b + 0.15 and b = 0.85*a
+			expectedScores: map[n.Node]float64{"a": 0.15 / 0.2775, "b": 0.85 * 0.15 / 0.2775},
+		},
+		"teleport to node not in graph should error": {
+			g:         setupDanglingDirGraph(),
+			teleport:  map[n.Node]float64{"x": 1},
+			shouldErr: true,
+		},
+		"negative teleport probability should error": {
+			g:         setupDanglingDirGraph(),
+			teleport:  map[n.Node]float64{"a": 2, "b": -1},
+			shouldErr: true,
+		},
+		"empty teleport should error": {
+			g:         setupDanglingDirGraph(),
+			teleport:  map[n.Node]float64{},
+			shouldErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scores, conv, err := PersonalizedPageRank(test.g, DefaultPageRankConfig(), test.teleport)
+			if test.shouldErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.True(t, conv.Converged)
+			for node, expected := range test.expectedScores {
+				assert.InDelta(t, expected, scores[node], pageRankTol)
+			}
+		})
+	}
+}
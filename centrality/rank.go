@@ -0,0 +1,36 @@
+package centrality
+
+import (
+	"sort"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// NodeScore pairs a node with its centrality score
+type NodeScore struct {
+	Node  n.Node
+	Score float64
+}
+
+// TopK returns the k nodes with the highest scores in order of decreasing score, breaking ties
+// lexicographically by node; all nodes are returned if there are fewer than k
+func TopK(scores map[n.Node]float64, k int) []NodeScore {
+	ranked := make([]NodeScore, 0, len(scores))
+	for node, score := range scores {
+		ranked = append(ranked, NodeScore{Node: node, Score: score})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Node < ranked[j].Node
+	})
+
+	if k < 0 {
+		k = 0
+	}
+	if k < len(ranked) {
+		ranked = ranked[:k]
+	}
+	return ranked
+}
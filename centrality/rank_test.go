@@ -0,0 +1,54 @@
+package centrality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func TestTopK(t *testing.T) {
+	scores := map[n.Node]float64{"a": 0.5, "b": 2, "c": 0.5, "d": 1, "e": 0}
+	tests := map[string]struct {
+		k        int
+		expected []NodeScore
+	}{
+		"zero nodes": {
+			k:        0,
+			expected: []NodeScore{},
+		},
+		"negative k": {
+			k:        -1,
+			expected: []NodeScore{},
+		},
+		"top node": {
+			k:        1,
+			expected: []NodeScore{{Node: "b", Score: 2}},
+		},
+		"ties broken by node": {
+			k: 3,
+			expected: []NodeScore{
+				{Node: "b", Score: 2},
+				{Node: "d", Score: 1},
+				{Node: "a", Score: 0.5},
+			},
+		},
+		"more than number of nodes": {
+			k: 10,
+			expected: []NodeScore{
+				{Node: "b", Score: 2},
+				{Node: "d", Score: 1},
+				{Node: "a", Score: 0.5},
+				{Node: "c", Score: 0.5},
+				{Node: "e", Score: 0},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TopK(scores, test.k))
+		})
+	}
+}
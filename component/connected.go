@@ -0,0 +1,122 @@
+package component
+
+import (
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// Connected computes the connected components of an undirected graph, returning each component
+// as a slice of nodes along with a map of each node to the index of its component
+func Connected(g *graph.Graph) ([][]n.Node, map[n.Node]int) {
+	return weaklyConnected(g)
+}
+
+// WeaklyConnected computes the weakly connected components of a directed graph, which are the connected
+// components when edge direction is ignored, returning each component as a slice of nodes along with
+// a map of each node to the index of its component
+func WeaklyConnected(dg *graph.DirGraph) ([][]n.Node, map[n.Node]int) {
+	return weaklyConnected(dg)
+}
+
+// weaklyConnected searches both the adjacency and inverse adjacency of a graph to find its components
+func weaklyConnected(g graph.DirectedGraph) ([][]n.Node, map[n.Node]int) {
+	comps := [][]n.Node{}
+	index := map[n.Node]int{}
+
+	for _, node := range g.GetNodes() {
+		if _, ok := index[node]; ok {
+			continue
+		}
+
+		compIdx := len(comps)
+		comp := []n.Node{}
+
+		q := n.NewQueue()
+		q.Push(node)
+		index[node] = compIdx
+		for q.Len() > 0 {
+			curNode, _ := q.Pop() // no need to check error since the queue cannot be empty here
+			comp = append(comp, curNode)
+
+			for _, getNbrs := range []func(n.Node) (map[n.Node]float64, bool){g.GetNeighbors, g.GetInvNeighbors} {
+				nbrs, ok := getNbrs(curNode)
+				if !ok {
+					continue
+				}
+				for nbr := range nbrs {
+					if _, ok := index[nbr]; ok {
+						continue
+					}
+					index[nbr] = compIdx
+					q.Push(nbr)
+				}
+			}
+		}
+		comps = append(comps, comp)
+	}
+
+	return comps, index
+}
+
+// Sizes returns the number of nodes in each component
+func Sizes(comps [][]n.Node) []int {
+	sizes := make([]int, len(comps))
+	for i, comp := range comps {
+		sizes[i] = len(comp)
+	}
+	return sizes
+}
+
+// Largest returns the index of the component with the most nodes, returning false if there are no components
+func Largest(comps [][]n.Node) (int, bool) {
+	if len(comps) == 0 {
+		return 0, false
+	}
+	largest := 0
+	for i, comp := range comps {
+		if len(comp) > len(comps[largest]) {
+			largest = i
+		}
+	}
+	return largest, true
+}
+
+// LargestConnected extracts the largest connected component of an undirected graph as a new graph
+func LargestConnected(g *graph.Graph) *graph.Graph {
+	sub, _ := graph.NewGraph(g.Name) // no need to check error since there are no readers
+	comps, _ := Connected(g)
+	if idx, ok := Largest(comps); ok {
+		copySubgraph(g, comps[idx], sub.AddNode, sub.AddEdge)
+	}
+	return sub
+}
+
+// LargestWeaklyConnected extracts the largest weakly connected component of a directed graph as a new directed graph
+func LargestWeaklyConnected(dg *graph.DirGraph) *graph.DirGraph {
+	sub, _ := graph.NewDirGraph(dg.Name) // no need to check error since there are no readers
+	comps, _ := WeaklyConnected(dg)
+	if idx, ok := Largest(comps); ok {
+		copySubgraph(dg, comps[idx], sub.AddNode, sub.AddEdge)
+	}
+	return sub
+}
+
+// copySubgraph adds each of a component's nodes and every edge from them using the specified adders;
+// since a component is closed under adjacency this copies exactly the component's edges
+func copySubgraph(
+	g graph.DirectedGraph,
+	comp []n.Node,
+	addNode func(n.Node),
+	addEdge func(n.Node, n.Node, ...float64),
+) {
+	for _, node := range comp {
+		addNode(node)
+		nbrs, ok := g.GetNeighbors(node)
+		if !ok {
+			continue
+		}
+		for nbr, wgt := range nbrs {
+			addEdge(node, nbr, wgt)
+		}
+	}
+}
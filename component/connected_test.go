@@ -0,0 +1,227 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupGraph() *graph.Graph {
+	g, _ := graph.NewGraph("components")
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("c", "a", 3)
+	g.AddEdge("c", "d", 4)
+	g.AddEdge("x", "y", 5)
+	g.AddEdge("y", "y", 6)
+	g.AddEdge("z", "z", 7)
+	g.AddNode("w")
+	return g
+}
+
+func assertComponents(t *testing.T, expectedComps [][]n.Node, comps [][]n.Node, index map[n.Node]int) {
+	assert.Len(t, comps, len(expectedComps))
+	numNodes := 0
+	for _, expected := range expectedComps {
+		numNodes += len(expected)
+		idx, ok := index[expected[0]]
+		assert.True(t, ok)
+		assert.ElementsMatch(t, expected, comps[idx])
+		for _, node := range expected {
+			assert.Equal(t, idx, index[node])
+		}
+	}
+	assert.Len(t, index, numNodes)
+}
+
+// assertSameNeighbors checks that two neighbor maps hold the same edges, treating a missing map as empty
+func assertSameNeighbors(t *testing.T, expected map[n.Node]float64, nbrs map[n.Node]float64) {
+	assert.Len(t, nbrs, len(expected))
+	for nbr, wgt := range expected {
+		assert.Equal(t, wgt, nbrs[nbr])
+	}
+}
+
+func TestConnected(t *testing.T) {
+	tests := map[string]struct {
+		g             *graph.Graph
+		expectedComps [][]n.Node
+	}{
+		"empty graph": {
+			g:             func() *graph.Graph { g, _ := graph.NewGraph("empty"); return g }(),
+			expectedComps: [][]n.Node{},
+		},
+		"graph with multiple components": {
+			g: setupGraph(),
+			expectedComps: [][]n.Node{
+				{"a", "b", "c", "d"},
+				{"x", "y"},
+				{"z"},
+				{"w"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			comps, index := Connected(test.g)
+			assertComponents(t, test.expectedComps, comps, index)
+		})
+	}
+}
+
+func TestWeaklyConnected(t *testing.T) {
+	tests := map[string]struct {
+		g             *graph.DirGraph
+		expectedComps [][]n.Node
+	}{
+		"empty graph": {
+			g:             func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+			expectedComps: [][]n.Node{},
+		},
+		"graph with strongly connected components": {
+			g: setupDirGraph(),
+			expectedComps: [][]n.Node{
+				{"a", "b", "c", "d", "e", "f", "g"},
+			},
+		},
+		"graph with isolated node": {
+			g: func() *graph.DirGraph {
+				g := setupDirGraph()
+				g.AddNode("h")
+				return g
+			}(),
+			expectedComps: [][]n.Node{
+				{"a", "b", "c", "d", "e", "f", "g"},
+				{"h"},
+			},
+		},
+		"graph reachable only against edge direction": {
+			g: func() *graph.DirGraph {
+				g, _ := graph.NewDirGraph("in star")
+				g.AddEdge("x", "z")
+				g.AddEdge("y", "z")
+				g.AddEdge("w", "w")
+				return g
+			}(),
+			expectedComps: [][]n.Node{
+				{"x", "y", "z"},
+				{"w"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			comps, index := WeaklyConnected(test.g)
+			assertComponents(t, test.expectedComps, comps, index)
+		})
+	}
+}
+
+func TestSizes(t *testing.T) {
+	tests := map[string]struct {
+		comps         [][]n.Node
+		expectedSizes []int
+	}{
+		"no components": {
+			comps:         [][]n.Node{},
+			expectedSizes: []int{},
+		},
+		"multiple components": {
+			comps:         [][]n.Node{{"a", "b"}, {"c"}, {"d", "e", "f"}},
+			expectedSizes: []int{2, 1, 3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedSizes, Sizes(test.comps))
+		})
+	}
+}
+
+func TestLargest(t *testing.T) {
+	tests := map[string]struct {
+		comps       [][]n.Node
+		expectedIdx int
+		expectedOk  bool
+	}{
+		"no components": {
+			comps:      [][]n.Node{},
+			expectedOk: false,
+		},
+		"multiple components": {
+			comps:       [][]n.Node{{"a", "b"}, {"c"}, {"d", "e", "f"}},
+			expectedIdx: 2,
+			expectedOk:  true,
+		},
+		"tie returns first largest component": {
+			comps:       [][]n.Node{{"a"}, {"b", "c"}, {"d", "e"}},
+			expectedIdx: 1,
+			expectedOk:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			idx, ok := Largest(test.comps)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedIdx, idx)
+		})
+	}
+}
+
+func TestLargestConnected(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		g, _ := graph.NewGraph("empty")
+		sub := LargestConnected(g)
+		assert.Equal(t, "empty", sub.Name)
+		assert.Empty(t, sub.GetNodes())
+	})
+	t.Run("graph of isolated node", func(t *testing.T) {
+		g, _ := graph.NewGraph("isolated")
+		g.AddNode("a")
+		sub := LargestConnected(g)
+		assert.Equal(t, []n.Node{"a"}, sub.GetNodes())
+	})
+	t.Run("graph with multiple components", func(t *testing.T) {
+		g := setupGraph()
+		sub := LargestConnected(g)
+		assert.Equal(t, g.Name, sub.Name)
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d"}, sub.GetNodes())
+		for _, node := range sub.GetNodes() {
+			nbrs, _ := g.GetNeighbors(node)
+			subNbrs, _ := sub.GetNeighbors(node)
+			assertSameNeighbors(t, nbrs, subNbrs)
+		}
+	})
+}
+
+func TestLargestWeaklyConnected(t *testing.T) {
+	t.Run("empty graph", func(t *testing.T) {
+		g, _ := graph.NewDirGraph("empty")
+		sub := LargestWeaklyConnected(g)
+		assert.Equal(t, "empty", sub.Name)
+		assert.Empty(t, sub.GetNodes())
+	})
+	t.Run("graph with multiple components", func(t *testing.T) {
+		g, _ := graph.NewDirGraph("test")
+		g.AddEdge("a", "b", 1.5)
+		g.AddEdge("c", "b", 2.5)
+		g.AddEdge("x", "y", 3)
+		sub := LargestWeaklyConnected(g)
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c"}, sub.GetNodes())
+		for _, node := range sub.GetNodes() {
+			nbrs, _ := g.GetNeighbors(node)
+			subNbrs, _ := sub.GetNeighbors(node)
+			assertSameNeighbors(t, nbrs, subNbrs)
+			invNbrs, _ := g.GetInvNeighbors(node)
+			subInvNbrs, _ := sub.GetInvNeighbors(node)
+			assertSameNeighbors(t, invNbrs, subInvNbrs)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package component
+
+import (
+	"strconv"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// StronglyConnected computes the strongly connected components of a directed graph using Kosaraju's
+// algorithm, returning each component as a slice of nodes along with a map of each node to the index
+// of its component; components are ordered topologically such that no edge leads to an earlier component
+func StronglyConnected(g graph.DirectedGraph) ([][]n.Node, map[n.Node]int) {
+	finished := finishOrder(g)
+
+	comps := [][]n.Node{}
+	index := map[n.Node]int{}
+
+	// search the inverse adjacency in order of decreasing finish time so that
+	// each search is confined to a single strongly connected component
+	for i := len(finished) - 1; i >= 0; i-- {
+		node := finished[i]
+		if _, ok := index[node]; ok {
+			continue
+		}
+
+		compIdx := len(comps)
+		comp := []n.Node{}
+
+		s := n.NewStack()
+		s.Push(node)
+		index[node] = compIdx
+		for s.Len() > 0 {
+			curNode, _ := s.Pop() // no need to check error since the stack cannot be empty here
+			comp = append(comp, curNode)
+
+			nbrs, ok := g.GetInvNeighbors(curNode)
+			if !ok {
+				continue
+			}
+			for nbr := range nbrs {
+				if _, ok := index[nbr]; ok {
+					continue
+				}
+				index[nbr] = compIdx
+				s.Push(nbr)
+			}
+		}
+		comps = append(comps, comp)
+	}
+
+	return comps, index
+}
+
+// finishOrder returns the nodes of a graph in the order that a depth first search finishes with them
+func finishOrder(g graph.DirectedGraph) []n.Node {
+	finished := []n.Node{}
+
+	visited := n.NewSet()
+	done := n.NewSet()
+
+	for _, node := range g.GetNodes() {
+		if visited.Contains(node) {
+			continue
+		}
+
+		s := n.NewStack()
+		s.Push(node)
+		for s.Len() > 0 {
+			curNode, _ := s.Pop() // no need to check error since the stack cannot be empty here
+			if done.Contains(curNode) {
+				continue
+			}
+			// a visited node is popped a second time only after all nodes
+			// pushed above it have been searched, at which point it is finished
+			if visited.Contains(curNode) {
+				done.Add(curNode)
+				finished = append(finished, curNode)
+				continue
+			}
+			visited.Add(curNode)
+			s.Push(curNode)
+
+			nbrs, ok := g.GetNeighbors(curNode)
+			if !ok {
+				continue
+			}
+			for nbr := range nbrs {
+				if !visited.Contains(nbr) {
+					s.Push(nbr)
+				}
+			}
+		}
+	}
+
+	return finished
+}
+
+// Condensation contracts each strongly connected component of a directed graph to a single node,
+// named by the component's index, producing a directed acyclic graph in which the weight of an edge
+// is the sum of the weights of all edges between the nodes of the two components
+func Condensation(g graph.DirectedGraph, name string) (*graph.DirGraph, [][]n.Node, map[n.Node]int) {
+	comps, index := StronglyConnected(g)
+
+	cg, _ := graph.NewDirGraph(name) // no need to check error since there are no readers
+
+	for srcIdx, comp := range comps {
+		src := componentNode(srcIdx)
+		cg.AddNode(src)
+
+		for _, node := range comp {
+			nbrs, ok := g.GetNeighbors(node)
+			if !ok {
+				continue
+			}
+			for nbr, wgt := range nbrs {
+				tgtIdx := index[nbr]
+				if tgtIdx == srcIdx {
+					continue
+				}
+				tgt := componentNode(tgtIdx)
+				if w, ok := cg.GetEdgeWeight(src, tgt); ok {
+					wgt += w
+				}
+				cg.AddEdge(src, tgt, wgt)
+			}
+		}
+	}
+
+	return cg, comps, index
+}
+
+// componentNode names the node representing a component by the component's index
+func componentNode(idx int) n.Node {
+	return n.Node(strconv.Itoa(idx))
+}
@@ -0,0 +1,148 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("components")
+	// cycle a -> b -> c -> a
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("c", "a", 3)
+	// cycle d <-> e with self loop on e
+	g.AddEdge("d", "e", 1.5)
+	g.AddEdge("e", "d", 2.5)
+	g.AddEdge("e", "e", 4)
+	// edges between cycles
+	g.AddEdge("c", "d", 0.5)
+	g.AddEdge("b", "e", 1.25)
+	// singletons f and g
+	g.AddEdge("e", "f", 7)
+	g.AddEdge("g", "f", 9)
+	return g
+}
+
+func TestStronglyConnected(t *testing.T) {
+	tests := map[string]struct {
+		g             *graph.DirGraph
+		expectedComps [][]n.Node
+	}{
+		"empty graph": {
+			g:             func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+			expectedComps: [][]n.Node{},
+		},
+		"graph with cycles": {
+			g: setupDirGraph(),
+			expectedComps: [][]n.Node{
+				{"a", "b", "c"},
+				{"d", "e"},
+				{"f"},
+				{"g"},
+			},
+		},
+		"single cycle": {
+			g: func() *graph.DirGraph {
+				g, _ := graph.NewDirGraph("cycle")
+				g.AddEdge("x", "y")
+				g.AddEdge("y", "z")
+				g.AddEdge("z", "x")
+				return g
+			}(),
+			expectedComps: [][]n.Node{{"x", "y", "z"}},
+		},
+		"isolated node": {
+			g: func() *graph.DirGraph {
+				g, _ := graph.NewDirGraph("isolated")
+				g.AddNode("x")
+				return g
+			}(),
+			expectedComps: [][]n.Node{{"x"}},
+		},
+		"chain": {
+			g: func() *graph.DirGraph {
+				g, _ := graph.NewDirGraph("chain")
+				g.AddEdge("x", "y")
+				g.AddEdge("y", "z")
+				return g
+			}(),
+			expectedComps: [][]n.Node{{"x"}, {"y"}, {"z"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			comps, index := StronglyConnected(test.g)
+			assert.Len(t, comps, len(test.expectedComps))
+			assert.Len(t, index, len(test.g.GetNodes()))
+
+			// test that each expected component was found and indexed
+			for _, expected := range test.expectedComps {
+				idx, ok := index[expected[0]]
+				assert.True(t, ok)
+				assert.ElementsMatch(t, expected, comps[idx])
+				for _, node := range expected {
+					assert.Equal(t, idx, index[node])
+				}
+			}
+
+			// test that components are in topological order
+			for src, srcIdx := range index {
+				nbrs, _ := test.g.GetNeighbors(src)
+				for tgt := range nbrs {
+					assert.LessOrEqual(t, srcIdx, index[tgt])
+				}
+			}
+		})
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	t.Run("condensation of graph with cycles", func(t *testing.T) {
+		g := setupDirGraph()
+		cg, comps, index := Condensation(g, "condensed")
+		assert.Equal(t, "condensed", cg.Name)
+		assert.Len(t, comps, 4)
+
+		abc := componentNode(index["a"])
+		de := componentNode(index["d"])
+		f := componentNode(index["f"])
+		gg := componentNode(index["g"])
+		assert.ElementsMatch(t, []n.Node{abc, de, f, gg}, cg.GetNodes())
+
+		expectedEdges := map[n.Node]map[n.Node]float64{
+			abc: {de: 1.75},
+			de:  {f: 7},
+			gg:  {f: 9},
+		}
+		for _, src := range cg.GetNodes() {
+			nbrs, _ := cg.GetNeighbors(src)
+			assert.Len(t, nbrs, len(expectedEdges[src]))
+			for tgt, wgt := range expectedEdges[src] {
+				w, ok := cg.GetEdgeWeight(src, tgt)
+				assert.True(t, ok)
+				assert.InEpsilon(t, wgt, w, 1e-9)
+			}
+		}
+	})
+}
+
+func TestCondensationIsolatedComponent(t *testing.T) {
+	t.Run("component without edges to other components is kept", func(t *testing.T) {
+		g := setupDirGraph()
+		g.AddEdge("x", "y")
+		g.AddEdge("y", "x")
+		cg, comps, index := Condensation(g, "condensed")
+		assert.Len(t, comps, 5)
+		xy := componentNode(index["x"])
+		assert.True(t, cg.HasNode(xy))
+		nbrs, _ := cg.GetNeighbors(xy)
+		assert.Empty(t, nbrs)
+		assert.Len(t, cg.GetNodes(), 5)
+	})
+}
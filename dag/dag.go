@@ -0,0 +1,172 @@
+package dag
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// CycleError is returned when a directed graph is not acyclic
+type CycleError struct {
+	// Cycle is a sequence of nodes such that each has an edge to the next
+	// and the last has an edge back to the first
+	Cycle []n.Node
+}
+
+func (e *CycleError) Error() string {
+	strs := make([]string, len(e.Cycle)+1)
+	for i, node := range e.Cycle {
+		strs[i] = string(node)
+	}
+	strs[len(e.Cycle)] = string(e.Cycle[0])
+	return fmt.Sprintf("graph contains a cycle: %s", strings.Join(strs, " -> "))
+}
+
+// readyQueue holds the nodes that have no remaining incoming edges
+type readyQueue interface {
+	Push(n.Node)
+	Pop() (n.Node, error)
+	Len() int
+}
+
+// TopologicalSort orders the nodes of a directed graph such that every edge is from a node to
+// a node later in the order, returning a *CycleError identifying a cycle if no such order exists
+func TopologicalSort(g graph.DirectedGraph) ([]n.Node, error) {
+	return kahn(g, n.NewQueue())
+}
+
+// LexicographicalTopologicalSort orders the nodes of a directed graph such that every edge is from a node
+// to a node later in the order, breaking ties by choosing the lexicographically smallest node so that
+// the result is deterministic, returning a *CycleError identifying a cycle if no such order exists
+func LexicographicalTopologicalSort(g graph.DirectedGraph) ([]n.Node, error) {
+	return kahn(g, &lexQueue{})
+}
+
+// kahn implements Kahn's algorithm, which repeatedly removes a node with no incoming edges
+func kahn(g graph.DirectedGraph, ready readyQueue) ([]n.Node, error) {
+	nodes := g.GetNodes()
+
+	inDeg := map[n.Node]int{}
+	for _, node := range nodes {
+		invNbrs, _ := g.GetInvNeighbors(node)
+		inDeg[node] = len(invNbrs)
+		if inDeg[node] == 0 {
+			ready.Push(node)
+		}
+	}
+
+	order := []n.Node{}
+	for ready.Len() > 0 {
+		curNode, _ := ready.Pop() // no need to check error since the queue cannot be empty here
+		order = append(order, curNode)
+
+		nbrs, ok := g.GetNeighbors(curNode)
+		if !ok {
+			continue
+		}
+		for nbr := range nbrs {
+			inDeg[nbr]--
+			if inDeg[nbr] == 0 {
+				ready.Push(nbr)
+			}
+		}
+	}
+
+	if len(order) < len(nodes) {
+		return order, &CycleError{Cycle: findCycle(g, inDeg)}
+	}
+	return order, nil
+}
+
+// findCycle finds a cycle among the nodes that Kahn's algorithm could not remove, each of
+// which must have an incoming edge from another such node; walking backwards along these
+// edges from the lexicographically smallest node must therefore eventually repeat a node
+func findCycle(g graph.DirectedGraph, inDeg map[n.Node]int) []n.Node {
+	remaining := []n.Node{}
+	for node, deg := range inDeg {
+		if deg > 0 {
+			remaining = append(remaining, node)
+		}
+	}
+	sortNodes(remaining)
+
+	path := []n.Node{}
+	position := map[n.Node]int{}
+	for cur := remaining[0]; ; {
+		if pos, ok := position[cur]; ok {
+			// the walk was backwards so reverse it to follow edge direction
+			cycle := path[pos:]
+			for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+				cycle[i], cycle[j] = cycle[j], cycle[i]
+			}
+			// rotate the cycle to start from its lexicographically smallest node
+			first := 0
+			for i, node := range cycle {
+				if node < cycle[first] {
+					first = i
+				}
+			}
+			rotated := append([]n.Node{}, cycle[first:]...)
+			return append(rotated, cycle[:first]...)
+		}
+		position[cur] = len(path)
+		path = append(path, cur)
+
+		invNbrs, _ := g.GetInvNeighbors(cur)
+		preds := []n.Node{}
+		for pred := range invNbrs {
+			if inDeg[pred] > 0 {
+				preds = append(preds, pred)
+			}
+		}
+		sortNodes(preds)
+		cur = preds[0]
+	}
+}
+
+func sortNodes(nodes []n.Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+}
+
+// nodeHeap implements heap.Interface as a min-heap of nodes in lexicographical order
+type nodeHeap []n.Node
+
+func (h nodeHeap) Len() int           { return len(h) }
+func (h nodeHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h nodeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *nodeHeap) Push(x interface{}) {
+	*h = append(*h, x.(n.Node))
+}
+
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	last := len(old) - 1
+	node := old[last]
+	*h = old[:last]
+	return node
+}
+
+// lexQueue is a readyQueue that pops nodes in lexicographical order
+type lexQueue struct {
+	items nodeHeap
+}
+
+func (q *lexQueue) Push(node n.Node) {
+	heap.Push(&q.items, node)
+}
+
+func (q *lexQueue) Pop() (n.Node, error) {
+	if q.items.Len() == 0 {
+		return "", n.ErrEmpty
+	}
+	return heap.Pop(&q.items).(n.Node), nil
+}
+
+func (q *lexQueue) Len() int {
+	return q.items.Len()
+}
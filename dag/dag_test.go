@@ -0,0 +1,158 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupDAG() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("dag")
+	g.AddEdge("compile", "link")
+	g.AddEdge("fetch", "compile")
+	g.AddEdge("configure", "compile")
+	g.AddEdge("link", "package")
+	g.AddEdge("test", "package")
+	g.AddEdge("compile", "test")
+	g.AddEdge("docs", "package")
+	return g
+}
+
+func setupCyclicGraph() *graph.DirGraph {
+	g := setupDAG()
+	g.AddEdge("package", "fetch")
+	return g
+}
+
+// assertTopological checks that every edge of a graph is from a node to a later node in an order
+func assertTopological(t *testing.T, g *graph.DirGraph, order []n.Node) {
+	assert.ElementsMatch(t, g.GetNodes(), order)
+	position := map[n.Node]int{}
+	for i, node := range order {
+		position[node] = i
+	}
+	for _, src := range g.GetNodes() {
+		nbrs, _ := g.GetNeighbors(src)
+		for tgt := range nbrs {
+			assert.Less(t, position[src], position[tgt])
+		}
+	}
+}
+
+// assertCycle checks that an error is a *CycleError holding a cycle of a graph
+func assertCycle(t *testing.T, g *graph.DirGraph, err error) {
+	cycleErr, ok := err.(*CycleError)
+	assert.True(t, ok)
+	assert.NotEmpty(t, cycleErr.Cycle)
+	for i, src := range cycleErr.Cycle {
+		tgt := cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]
+		assert.True(t, g.HasEdge(src, tgt))
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	tests := map[string]struct {
+		g         *graph.DirGraph
+		shouldErr bool
+	}{
+		"empty graph": {
+			g: func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+		},
+		"acyclic graph": {
+			g: setupDAG(),
+		},
+		"cyclic graph should error": {
+			g:         setupCyclicGraph(),
+			shouldErr: true,
+		},
+		"graph with self loop should error": {
+			g: func() *graph.DirGraph {
+				g := setupDAG()
+				g.AddEdge("docs", "docs")
+				return g
+			}(),
+			shouldErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			order, err := TopologicalSort(test.g)
+			if test.shouldErr {
+				assertCycle(t, test.g, err)
+				return
+			}
+			assert.Nil(t, err)
+			assertTopological(t, test.g, order)
+		})
+	}
+}
+
+func TestLexicographicalTopologicalSort(t *testing.T) {
+	tests := map[string]struct {
+		g             *graph.DirGraph
+		expectedOrder []n.Node
+		expectedCycle []n.Node
+	}{
+		"empty graph": {
+			g:             func() *graph.DirGraph { g, _ := graph.NewDirGraph("empty"); return g }(),
+			expectedOrder: []n.Node{},
+		},
+		"acyclic graph": {
+			g:             setupDAG(),
+			expectedOrder: []n.Node{"configure", "docs", "fetch", "compile", "link", "test", "package"},
+		},
+		"cyclic graph should error": {
+			g:             setupCyclicGraph(),
+			expectedOrder: []n.Node{"configure", "docs"},
+			expectedCycle: []n.Node{"compile", "link", "package", "fetch"},
+		},
+		"graph with self loop should error": {
+			g: func() *graph.DirGraph {
+				g := setupDAG()
+				g.AddEdge("docs", "docs")
+				return g
+			}(),
+			expectedOrder: []n.Node{"configure", "fetch", "compile", "link", "test"},
+			expectedCycle: []n.Node{"docs"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			order, err := LexicographicalTopologicalSort(test.g)
+			assert.Equal(t, test.expectedOrder, order)
+			if test.expectedCycle != nil {
+				assertCycle(t, test.g, err)
+				assert.Equal(t, test.expectedCycle, err.(*CycleError).Cycle)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestCycleErrorError(t *testing.T) {
+	tests := map[string]struct {
+		err         *CycleError
+		expectedMsg string
+	}{
+		"self loop": {
+			err:         &CycleError{Cycle: []n.Node{"a"}},
+			expectedMsg: "graph contains a cycle: a -> a",
+		},
+		"longer cycle": {
+			err:         &CycleError{Cycle: []n.Node{"a", "b", "c"}},
+			expectedMsg: "graph contains a cycle: a -> b -> c -> a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedMsg, test.err.Error())
+		})
+	}
+}
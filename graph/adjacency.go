@@ -2,62 +2,69 @@ package graph
 
 import (
 	"fmt"
-
-	n "github.com/dkaslovsky/GoGraph/node"
 )
 
-type dirAdj map[n.Node]map[n.Node]float64
+// dirAdj is a directed adjacency map from each source node to its target nodes and the weights of the edges
+type dirAdj[T comparable] map[T]map[T]float64
 
 // Print prints the adjacency structure
-func (a dirAdj) Print() {
+func (a dirAdj[T]) Print() {
 	for node, nbrs := range a {
-		fmt.Printf("%s:\n", node)
+		fmt.Printf("%v:\n", node)
 		for n, wgt := range nbrs {
-			fmt.Printf(" -->  %s: %f\n", n, wgt)
+			fmt.Printf(" -->  %v: %f\n", n, wgt)
 		}
 	}
 }
 
-func (a dirAdj) addDirectedEdge(src n.Node, tgt n.Node, wgt float64) {
+func (a dirAdj[T]) addDirectedEdge(src T, tgt T, wgt float64) {
 	if nbrs, ok := a[src]; ok {
 		nbrs[tgt] = wgt
 		return
 	}
-	a[src] = map[n.Node]float64{tgt: wgt}
+	a[src] = map[T]float64{tgt: wgt}
+}
+
+func (a dirAdj[T]) addNode(node T) {
+	if _, ok := a[node]; ok {
+		return
+	}
+	a[node] = map[T]float64{}
 }
 
-func (a dirAdj) removeDirectedEdge(src n.Node, tgt n.Node) {
+// removeDirectedEdge removes an edge but keeps its src node even if it no longer has neighbors
+func (a dirAdj[T]) removeDirectedEdge(src T, tgt T) {
 	nbrs, ok := a[src]
 	if !ok {
 		return
 	}
 	delete(nbrs, tgt)
-	// delete src node if it no longer has neighbors
-	if len(nbrs) == 0 {
-		delete(a, src)
-	}
 }
 
-func (a dirAdj) getSrcNodes() (nodes []n.Node) {
+func (a dirAdj[T]) removeSrcNode(node T) {
+	delete(a, node)
+}
+
+func (a dirAdj[T]) getSrcNodes() (nodes []T) {
 	for node := range a {
 		nodes = append(nodes, node)
 	}
 	return nodes
 }
 
-func (a dirAdj) hasSrcNode(node n.Node) bool {
+func (a dirAdj[T]) hasSrcNode(node T) bool {
 	_, ok := a[node]
 	return ok
 }
 
 // GetNeighbors gets the nodes that a specified node connects to with an edge
-func (a dirAdj) GetNeighbors(node n.Node) (map[n.Node]float64, bool) {
+func (a dirAdj[T]) GetNeighbors(node T) (map[T]float64, bool) {
 	nbrs, ok := a[node]
 	return nbrs, ok
 }
 
 // GetOutDegree calculates the sum of weights of all edges with node as the source node
-func (a dirAdj) GetOutDegree(node n.Node) (deg float64, found bool) {
+func (a dirAdj[T]) GetOutDegree(node T) (deg float64, found bool) {
 	nbrs, ok := a.GetNeighbors(node)
 	if !ok {
 		return deg, false
@@ -69,7 +76,7 @@ func (a dirAdj) GetOutDegree(node n.Node) (deg float64, found bool) {
 }
 
 // HasEdge returns true if an edge exists from a node to another node, false otherwise
-func (a dirAdj) HasEdge(src n.Node, tgt n.Node) bool {
+func (a dirAdj[T]) HasEdge(src T, tgt T) bool {
 	nbrs, ok := a.GetNeighbors(src)
 	if !ok {
 		return false
@@ -79,7 +86,7 @@ func (a dirAdj) HasEdge(src n.Node, tgt n.Node) bool {
 }
 
 // GetEdgeWeight returns the weight of the edge from a node to another node if it exists
-func (a dirAdj) GetEdgeWeight(src n.Node, tgt n.Node) (weight float64, found bool) {
+func (a dirAdj[T]) GetEdgeWeight(src T, tgt T) (weight float64, found bool) {
 	if !a.HasEdge(src, tgt) {
 		return weight, false
 	}
@@ -17,8 +17,8 @@ type testEdge struct {
 	wgt float64
 }
 
-func setupAdj() dirAdj {
-	return dirAdj{
+func setupAdj() dirAdj[n.Node] {
+	return dirAdj[n.Node]{
 		"x": {"y": 1, "z": 1},
 		"y": {"x": 3.2, "z": 9.7},
 		"z": {"x": 2.2, "z": 3.4},
@@ -27,23 +27,23 @@ func setupAdj() dirAdj {
 
 func TestAddDirectedEdge(t *testing.T) {
 	tests := map[string]struct {
-		a dirAdj
+		a dirAdj[n.Node]
 		testEdge
 	}{
 		"add edge with integer weight": {
-			dirAdj{},
+			dirAdj[n.Node]{},
 			testEdge{src: "a", tgt: "b", wgt: 1},
 		},
 		"add edge with float weight": {
-			dirAdj{},
+			dirAdj[n.Node]{},
 			testEdge{src: "a", tgt: "b", wgt: 3.4},
 		},
 		"upsert edge": {
-			dirAdj{"a": {"b": 3.4}},
+			dirAdj[n.Node]{"a": {"b": 3.4}},
 			testEdge{src: "a", tgt: "b", wgt: 10.10},
 		},
 		"add self loop": {
-			dirAdj{},
+			dirAdj[n.Node]{},
 			testEdge{src: "a", tgt: "a", wgt: 1.1},
 		},
 	}
@@ -64,34 +64,66 @@ func TestAddDirectedEdge(t *testing.T) {
 	}
 }
 
+func TestAddNode(t *testing.T) {
+	tests := map[string]struct {
+		node         n.Node
+		expectedNbrs map[n.Node]float64
+	}{
+		"add new node": {
+			node:         "a",
+			expectedNbrs: map[n.Node]float64{},
+		},
+		"add existing node does not remove its neighbors": {
+			node:         "x",
+			expectedNbrs: map[n.Node]float64{"y": 1, "z": 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := setupAdj()
+			a.addNode(test.node)
+			nbrs, ok := a[test.node]
+			assert.True(t, ok)
+			assert.Equal(t, test.expectedNbrs, nbrs)
+		})
+	}
+}
+
 func TestRemoveDirectedEdge(t *testing.T) {
 	tests := map[string]struct {
 		src           n.Node
 		tgts          []n.Node
 		tgtsRemaining []n.Node
+		srcExists     bool
 	}{
 		"remove nonexistent edge from existing node": {
 			src:           "x",
 			tgts:          []n.Node{"foo"},
 			tgtsRemaining: []n.Node{"y", "z"},
+			srcExists:     true,
 		},
 		"remove nonexistent edge from nonexistent node": {
-			src:  "foo",
-			tgts: []n.Node{"bar"},
+			src:       "foo",
+			tgts:      []n.Node{"bar"},
+			srcExists: false,
 		},
 		"remove existing edge": {
 			src:           "x",
 			tgts:          []n.Node{"y"},
 			tgtsRemaining: []n.Node{"z"},
+			srcExists:     true,
 		},
 		"remove all edges from node": {
-			src:  "y",
-			tgts: []n.Node{"x", "z"},
+			src:       "y",
+			tgts:      []n.Node{"x", "z"},
+			srcExists: true,
 		},
 		"remove self loop": {
 			src:           "z",
 			tgts:          []n.Node{"z"},
 			tgtsRemaining: []n.Node{"x"},
+			srcExists:     true,
 		},
 	}
 
@@ -103,18 +135,18 @@ func TestRemoveDirectedEdge(t *testing.T) {
 			}
 
 			nbrs, ok := a[test.src]
-			// src should be removed if no target nodes remain
-			if len(test.tgtsRemaining) == 0 {
-				assert.False(t, ok)
+			// src should remain even if no target nodes remain
+			assert.Equal(t, test.srcExists, ok)
+			if !test.srcExists {
 				return
 			}
-			assert.True(t, ok)
 
 			// test that only the specified nodes were
 			// removed and the others remain
 			for _, tgt := range test.tgts {
 				assert.NotContains(t, nbrs, tgt)
 			}
+			assert.Len(t, nbrs, len(test.tgtsRemaining))
 			for _, tgt := range test.tgtsRemaining {
 				assert.Contains(t, nbrs, tgt)
 			}
@@ -122,13 +154,37 @@ func TestRemoveDirectedEdge(t *testing.T) {
 	}
 }
 
+func TestRemoveSrcNode(t *testing.T) {
+	tests := map[string]struct {
+		node          n.Node
+		expectedNodes []n.Node
+	}{
+		"remove nonexistent node": {
+			node:          "a",
+			expectedNodes: []n.Node{"x", "y", "z"},
+		},
+		"remove existing node": {
+			node:          "y",
+			expectedNodes: []n.Node{"x", "z"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := setupAdj()
+			a.removeSrcNode(test.node)
+			assert.ElementsMatch(t, test.expectedNodes, a.getSrcNodes())
+		})
+	}
+}
+
 func TestGetSrcNodes(t *testing.T) {
 	tests := map[string]struct {
-		a             dirAdj
+		a             dirAdj[n.Node]
 		expectedNodes []n.Node
 	}{
 		"empty adjacency": {
-			a:             dirAdj{},
+			a:             dirAdj[n.Node]{},
 			expectedNodes: []n.Node{},
 		},
 		"nonempty adjacency": {
@@ -0,0 +1,195 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// Attributes is a collection of key/value properties of a node or an edge
+type Attributes map[string]interface{}
+
+// GetString returns the value of an attribute formatted as a string
+func (a Attributes) GetString(key string) (string, bool) {
+	val, ok := a[key]
+	if !ok {
+		return "", false
+	}
+	if s, ok := val.(string); ok {
+		return s, true
+	}
+	return fmt.Sprint(val), true
+}
+
+// GetFloat returns the value of a numeric attribute or of a string attribute that parses as a float64
+func (a Attributes) GetFloat(key string) (float64, bool) {
+	switch val := a[key].(type) {
+	case float64:
+		return val, true
+	case float32:
+		return float64(val), true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// GetInt returns the value of an integer attribute or of a string attribute that parses as an int
+func (a Attributes) GetInt(key string) (int, bool) {
+	switch val := a[key].(type) {
+	case int:
+		return val, true
+	case int64:
+		return int(val), true
+	case string:
+		i, err := strconv.Atoi(val)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// GetBool returns the value of a bool attribute or of a string attribute that parses as a bool
+func (a Attributes) GetBool(key string) (bool, bool) {
+	switch val := a[key].(type) {
+	case bool:
+		return val, true
+	case string:
+		b, err := strconv.ParseBool(val)
+		return b, err == nil
+	}
+	return false, false
+}
+
+// GetTime returns the value of a time attribute or of a string attribute that parses as an RFC 3339 time
+func (a Attributes) GetTime(key string) (time.Time, bool) {
+	switch val := a[key].(type) {
+	case time.Time:
+		return val, true
+	case string:
+		t, err := time.Parse(time.RFC3339, val)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// formatAttrValue formats the value of an attribute as a string that can be parsed by the typed getters
+func formatAttrValue(val interface{}) string {
+	if t, ok := val.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(val)
+}
+
+// attrAdj holds the attributes of edges keyed by their src and tgt nodes
+type attrAdj map[n.Node]map[n.Node]Attributes
+
+func (a attrAdj) get(src n.Node, tgt n.Node) (Attributes, bool) {
+	attrs, ok := a[src][tgt]
+	return attrs, ok
+}
+
+func (a attrAdj) set(src n.Node, tgt n.Node, attrs Attributes) {
+	if _, ok := a[src]; !ok {
+		a[src] = map[n.Node]Attributes{}
+	}
+	a[src][tgt] = attrs
+}
+
+func (a attrAdj) remove(src n.Node, tgt n.Node) {
+	nbrs, ok := a[src]
+	if !ok {
+		return
+	}
+	delete(nbrs, tgt)
+	if len(nbrs) == 0 {
+		delete(a, src)
+	}
+}
+
+// SetNodeAttr sets an attribute of a node, adding the node if it does not already exist
+func (g *Graph) SetNodeAttr(node n.Node, key string, value interface{}) {
+	g.AddNode(node)
+	attrs, ok := g.nodeAttrs[node]
+	if !ok {
+		attrs = Attributes{}
+		g.nodeAttrs[node] = attrs
+	}
+	attrs[key] = value
+}
+
+// GetNodeAttrs gets the attributes of a node
+func (g *Graph) GetNodeAttrs(node n.Node) (Attributes, bool) {
+	// a DirGraph calls this method on its embedded Graph so the node is checked in both adjacency maps,
+	// which for an undirected graph are the same map, to find nodes that only have incoming edges
+	if !g.hasDirNode(node) {
+		return nil, false
+	}
+	if attrs, ok := g.nodeAttrs[node]; ok {
+		return attrs, true
+	}
+	return Attributes{}, true
+}
+
+// DeleteNodeAttr removes an attribute from a node
+func (g *Graph) DeleteNodeAttr(node n.Node, key string) {
+	attrs, ok := g.nodeAttrs[node]
+	if !ok {
+		return
+	}
+	delete(attrs, key)
+	if len(attrs) == 0 {
+		delete(g.nodeAttrs, node)
+	}
+}
+
+// SetEdgeAttr sets an attribute of an existing edge, returning false if the edge does not exist
+func (g *Graph) SetEdgeAttr(src n.Node, tgt n.Node, key string, value interface{}) bool {
+	if !g.HasEdge(src, tgt) {
+		return false
+	}
+	attrs, ok := g.edgeAttrs.get(src, tgt)
+	if !ok {
+		attrs = Attributes{}
+		// the inverse holds the same attributes so that they are shared by both directions of
+		// an undirected edge and can be found from the tgt node of a directed edge
+		g.edgeAttrs.set(src, tgt, attrs)
+		g.invEdgeAttrs.set(tgt, src, attrs)
+	}
+	attrs[key] = value
+	return true
+}
+
+// GetEdgeAttrs gets the attributes of an edge
+func (g *Graph) GetEdgeAttrs(src n.Node, tgt n.Node) (Attributes, bool) {
+	if !g.HasEdge(src, tgt) {
+		return nil, false
+	}
+	if attrs, ok := g.edgeAttrs.get(src, tgt); ok {
+		return attrs, true
+	}
+	return Attributes{}, true
+}
+
+// DeleteEdgeAttr removes an attribute from an edge
+func (g *Graph) DeleteEdgeAttr(src n.Node, tgt n.Node, key string) {
+	attrs, ok := g.edgeAttrs.get(src, tgt)
+	if !ok {
+		return
+	}
+	delete(attrs, key)
+	if len(attrs) == 0 {
+		g.removeEdgeAttrs(src, tgt)
+	}
+}
+
+func (g *Graph) removeEdgeAttrs(src n.Node, tgt n.Node) {
+	g.edgeAttrs.remove(src, tgt)
+	g.invEdgeAttrs.remove(tgt, src)
+}
@@ -0,0 +1,314 @@
+package graph
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupAttributes() Attributes {
+	return Attributes{
+		"label":     "foo",
+		"float":     2.5,
+		"int":       7,
+		"bool":      true,
+		"time":      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		"strFloat":  "1.25",
+		"strInt":    "12",
+		"strBool":   "false",
+		"strTime":   "2021-06-07T08:09:10Z",
+		"notNumber": "abc",
+	}
+}
+
+func TestAttributesGetString(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedVal string
+		expectedOk  bool
+	}{
+		"missing key":      {key: "x", expectedVal: "", expectedOk: false},
+		"string attribute": {key: "label", expectedVal: "foo", expectedOk: true},
+		"float attribute":  {key: "float", expectedVal: "2.5", expectedOk: true},
+		"bool attribute":   {key: "bool", expectedVal: "true", expectedOk: true},
+	}
+
+	a := setupAttributes()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, ok := a.GetString(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedVal, val)
+		})
+	}
+}
+
+func TestAttributesGetFloat(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedVal float64
+		expectedOk  bool
+	}{
+		"missing key":               {key: "x", expectedOk: false},
+		"float attribute":           {key: "float", expectedVal: 2.5, expectedOk: true},
+		"int attribute":             {key: "int", expectedVal: 7, expectedOk: true},
+		"parseable string":          {key: "strFloat", expectedVal: 1.25, expectedOk: true},
+		"unparseable string":        {key: "notNumber", expectedOk: false},
+		"attribute of another type": {key: "bool", expectedOk: false},
+	}
+
+	a := setupAttributes()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, ok := a.GetFloat(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedVal, val)
+		})
+	}
+}
+
+func TestAttributesGetInt(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedVal int
+		expectedOk  bool
+	}{
+		"missing key":               {key: "x", expectedOk: false},
+		"int attribute":             {key: "int", expectedVal: 7, expectedOk: true},
+		"parseable string":          {key: "strInt", expectedVal: 12, expectedOk: true},
+		"unparseable string":        {key: "strFloat", expectedOk: false},
+		"attribute of another type": {key: "float", expectedOk: false},
+	}
+
+	a := setupAttributes()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, ok := a.GetInt(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedVal, val)
+		})
+	}
+}
+
+func TestAttributesGetBool(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedVal bool
+		expectedOk  bool
+	}{
+		"missing key":               {key: "x", expectedOk: false},
+		"bool attribute":            {key: "bool", expectedVal: true, expectedOk: true},
+		"parseable string":          {key: "strBool", expectedVal: false, expectedOk: true},
+		"unparseable string":        {key: "label", expectedOk: false},
+		"attribute of another type": {key: "int", expectedOk: false},
+	}
+
+	a := setupAttributes()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, ok := a.GetBool(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedVal, val)
+		})
+	}
+}
+
+func TestAttributesGetTime(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedVal time.Time
+		expectedOk  bool
+	}{
+		"missing key":               {key: "x", expectedOk: false},
+		"time attribute":            {key: "time", expectedVal: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), expectedOk: true},
+		"parseable string":          {key: "strTime", expectedVal: time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC), expectedOk: true},
+		"unparseable string":        {key: "label", expectedOk: false},
+		"attribute of another type": {key: "int", expectedOk: false},
+	}
+
+	a := setupAttributes()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, ok := a.GetTime(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.True(t, test.expectedVal.Equal(val))
+		})
+	}
+}
+
+func TestGraphNodeAttrs(t *testing.T) {
+	t.Run("set, get and delete node attributes", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+
+		attrs, ok := g.GetNodeAttrs("a")
+		assert.True(t, ok)
+		assert.Empty(t, attrs)
+		_, ok = g.GetNodeAttrs("x")
+		assert.False(t, ok)
+
+		g.SetNodeAttr("a", "label", "first")
+		g.SetNodeAttr("a", "rank", 1)
+		attrs, ok = g.GetNodeAttrs("a")
+		assert.True(t, ok)
+		assert.Equal(t, Attributes{"label": "first", "rank": 1}, attrs)
+
+		g.DeleteNodeAttr("a", "rank")
+		attrs, _ = g.GetNodeAttrs("a")
+		assert.Equal(t, Attributes{"label": "first"}, attrs)
+	})
+	t.Run("setting attribute of nonexistent node adds the node", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.SetNodeAttr("a", "label", "first")
+		assert.True(t, g.HasNode("a"))
+		attrs, ok := g.GetNodeAttrs("a")
+		assert.True(t, ok)
+		assert.Equal(t, Attributes{"label": "first"}, attrs)
+	})
+	t.Run("removing node removes its attributes", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		dg.SetNodeAttr("b", "label", "second")
+		dg.RemoveNode("b")
+		assert.NotContains(t, dg.nodeAttrs, n.Node("b"))
+		dg.AddNode("b")
+		attrs, _ := dg.GetNodeAttrs("b")
+		assert.Empty(t, attrs)
+	})
+	t.Run("node with only incoming edges", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		assert.True(t, dg.HasNode("b"))
+		attrs, ok := dg.GetNodeAttrs("b")
+		assert.True(t, ok)
+		assert.Empty(t, attrs)
+
+		dg.SetNodeAttr("b", "label", "sink")
+		attrs, ok = dg.GetNodeAttrs("b")
+		assert.True(t, ok)
+		assert.Equal(t, Attributes{"label": "sink"}, attrs)
+
+		cdg, _ := NewConcurrentDirGraph("test")
+		cdg.AddEdge("a", "b")
+		attrs, ok = cdg.GetNodeAttrs("b")
+		assert.True(t, ok)
+		assert.Empty(t, attrs)
+	})
+}
+
+func TestGraphEdgeAttrs(t *testing.T) {
+	t.Run("undirected edge attributes are shared by both directions", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+		assert.True(t, g.SetEdgeAttr("a", "b", "color", "red"))
+		attrs, ok := g.GetEdgeAttrs("b", "a")
+		assert.True(t, ok)
+		assert.Equal(t, Attributes{"color": "red"}, attrs)
+
+		assert.True(t, g.SetEdgeAttr("b", "a", "color", "blue"))
+		attrs, _ = g.GetEdgeAttrs("a", "b")
+		assert.Equal(t, Attributes{"color": "blue"}, attrs)
+	})
+	t.Run("directed edge attributes belong to one direction", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		dg.AddEdge("b", "a")
+		assert.True(t, dg.SetEdgeAttr("a", "b", "color", "red"))
+		attrs, _ := dg.GetEdgeAttrs("b", "a")
+		assert.Empty(t, attrs)
+		attrs, _ = dg.GetEdgeAttrs("a", "b")
+		assert.Equal(t, Attributes{"color": "red"}, attrs)
+		invAttrs, ok := dg.invEdgeAttrs.get("b", "a")
+		assert.True(t, ok)
+		assert.Equal(t, attrs, invAttrs)
+	})
+	t.Run("attributes of nonexistent edge", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		assert.False(t, dg.SetEdgeAttr("b", "a", "color", "red"))
+		_, ok := dg.GetEdgeAttrs("b", "a")
+		assert.False(t, ok)
+	})
+	t.Run("deleting last attribute removes attributes from both adjacencies", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		dg.SetEdgeAttr("a", "b", "color", "red")
+		dg.DeleteEdgeAttr("a", "b", "color")
+		assert.Empty(t, *dg.edgeAttrs)
+		assert.Empty(t, *dg.invEdgeAttrs)
+	})
+}
+
+func TestGraphEdgeAttrsRemoved(t *testing.T) {
+	tests := map[string]struct {
+		remove func(*DirGraph)
+	}{
+		"remove edge": {
+			remove: func(dg *DirGraph) { dg.RemoveEdge("a", "b") },
+		},
+		"remove src node": {
+			remove: func(dg *DirGraph) { dg.RemoveNode("a") },
+		},
+		"remove tgt node": {
+			remove: func(dg *DirGraph) { dg.RemoveNode("b") },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dg, _ := NewDirGraph("test")
+			dg.AddEdge("a", "b")
+			dg.SetEdgeAttr("a", "b", "color", "red")
+			test.remove(dg)
+			assert.Empty(t, *dg.edgeAttrs)
+			assert.Empty(t, *dg.invEdgeAttrs)
+			// re-adding the edge does not restore its attributes
+			dg.AddEdge("a", "b")
+			attrs, _ := dg.GetEdgeAttrs("a", "b")
+			assert.Empty(t, attrs)
+		})
+	}
+
+	t.Run("remove undirected edge from either direction", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+		g.SetEdgeAttr("a", "b", "color", "red")
+		g.RemoveEdge("b", "a")
+		assert.Empty(t, *g.edgeAttrs)
+	})
+}
+
+func TestReadAttributes(t *testing.T) {
+	t.Run("read node and edge attributes", func(t *testing.T) {
+		f := []byte("a label=first\na b 1.5 color=red since=2020-01-02T03:04:05Z\nb c 1 kind=friend\nc")
+		reader := ioutil.NopCloser(bytes.NewReader(f))
+		g, _, err := EdgeListReader{Attributes: true}.NewDirGraph("test", reader)
+		assert.Nil(t, err)
+
+		attrs, _ := g.GetNodeAttrs("a")
+		assert.Equal(t, Attributes{"label": "first"}, attrs)
+		attrs, _ = g.GetNodeAttrs("c")
+		assert.Empty(t, attrs)
+
+		w, _ := g.GetEdgeWeight("a", "b")
+		assert.Equal(t, 1.5, w)
+		attrs, _ = g.GetEdgeAttrs("a", "b")
+		assert.Equal(t, Attributes{"color": "red", "since": "2020-01-02T03:04:05Z"}, attrs)
+		since, ok := attrs.GetTime("since")
+		assert.True(t, ok)
+		assert.Equal(t, 2020, since.Year())
+
+		attrs, _ = g.GetEdgeAttrs("b", "c")
+		assert.Equal(t, Attributes{"kind": "friend"}, attrs)
+	})
+	t.Run("attribute with empty key should error", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b 1 =red")))
+		_, _, err := EdgeListReader{Attributes: true}.NewGraph("test", reader)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,260 @@
+package graph
+
+import (
+	"sort"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// csr holds the edges of each node in compressed sparse row format, in which the targets and weights of
+// the edges of the node with id i are found at positions ptr[i] through ptr[i+1]-1 sorted by target id
+// and found[i] records whether the source graph found the node when getting its edges
+type csr struct {
+	ptr   []int
+	tgts  []int
+	wgts  []float64
+	found []bool
+}
+
+func (c *csr) row(id int) ([]int, []float64) {
+	start, end := c.ptr[id], c.ptr[id+1]
+	return c.tgts[start:end], c.wgts[start:end]
+}
+
+// find returns the position of the edge from src to tgt
+func (c *csr) find(src int, tgt int) (int, bool) {
+	start, end := c.ptr[src], c.ptr[src+1]
+	k := start + sort.SearchInts(c.tgts[start:end], tgt)
+	return k, k < end && c.tgts[k] == tgt
+}
+
+func (c *csr) degree(id int) (deg float64) {
+	_, wgts := c.row(id)
+	for _, w := range wgts {
+		deg += w
+	}
+	return deg
+}
+
+// CompactGraph is an immutable graph that interns its nodes as dense integer ids and stores its edges
+// in compressed sparse row arrays, using much less memory than a Graph or DirGraph for large graphs;
+// its methods return the same values as those of the graph it was built from, so a node of a directed
+// graph that has only incoming edges is not found by GetNeighbors, GetOutDegree or RangeNeighbors
+type CompactGraph struct {
+	Name     string
+	directed bool
+	nodes    []n.Node
+	ids      map[n.Node]int
+	out      *csr
+	in       *csr
+}
+
+// Compact creates a CompactGraph with the nodes and edges of a Graph
+func (g *Graph) Compact() *CompactGraph {
+	return newCompactGraph(g.Name, g)
+}
+
+// Compact creates a CompactGraph with the nodes and edges of a DirGraph
+func (dg *DirGraph) Compact() *CompactGraph {
+	return newCompactGraph(dg.Name, dg)
+}
+
+// newCompactGraph assigns ids to nodes in sorted order so that the ids of a graph are stable across runs
+func newCompactGraph(name string, g DirectedGraph) *CompactGraph {
+	nodes := g.GetNodes()
+	sortNodes(nodes)
+
+	cg := &CompactGraph{
+		Name:     name,
+		directed: g.Directed(),
+		nodes:    nodes,
+		ids:      make(map[n.Node]int, len(nodes)),
+	}
+	for id, node := range nodes {
+		cg.ids[node] = id
+	}
+
+	cg.out = cg.buildCSR(g.GetNeighbors)
+	if cg.directed {
+		cg.in = cg.buildCSR(g.GetInvNeighbors)
+	} else {
+		// the incoming edges of an undirected graph are its outgoing edges
+		cg.in = cg.out
+	}
+	return cg
+}
+
+func (cg *CompactGraph) buildCSR(getNeighbors func(n.Node) (map[n.Node]float64, bool)) *csr {
+	c := &csr{ptr: make([]int, len(cg.nodes)+1), found: make([]bool, len(cg.nodes))}
+	for id, node := range cg.nodes {
+		nbrs, ok := getNeighbors(node)
+		c.found[id] = ok
+		start := len(c.tgts)
+		for nbr := range nbrs {
+			c.tgts = append(c.tgts, cg.ids[nbr])
+		}
+		sort.Ints(c.tgts[start:])
+		for _, tgt := range c.tgts[start:] {
+			c.wgts = append(c.wgts, nbrs[cg.nodes[tgt]])
+		}
+		c.ptr[id+1] = len(c.tgts)
+	}
+	return c
+}
+
+// Directed returns true if the graph is directed
+func (cg *CompactGraph) Directed() bool {
+	return cg.directed
+}
+
+// NumNodes returns the number of nodes in the graph
+func (cg *CompactGraph) NumNodes() int {
+	return len(cg.nodes)
+}
+
+// NumEdges returns the number of edges in the graph, counting each undirected edge in both directions
+func (cg *CompactGraph) NumEdges() int {
+	return len(cg.out.tgts)
+}
+
+// ID gets the integer id of a node
+func (cg *CompactGraph) ID(node n.Node) (int, bool) {
+	id, ok := cg.ids[node]
+	return id, ok
+}
+
+// Node gets the node with an integer id
+func (cg *CompactGraph) Node(id int) (n.Node, bool) {
+	if id < 0 || id >= len(cg.nodes) {
+		return "", false
+	}
+	return cg.nodes[id], true
+}
+
+// Neighbors gets the ids of the nodes that the node with an id has an edge to and the weights of the edges,
+// sorted by id; the returned slices are shared with the graph and must not be modified
+func (cg *CompactGraph) Neighbors(id int) ([]int, []float64) {
+	return cg.out.row(id)
+}
+
+// InvNeighbors gets the ids of the nodes that have an edge to the node with an id and the weights of the
+// edges, sorted by id; the returned slices are shared with the graph and must not be modified
+func (cg *CompactGraph) InvNeighbors(id int) ([]int, []float64) {
+	return cg.in.row(id)
+}
+
+// GetNodes gets a slice of all nodes ordered by id
+func (cg *CompactGraph) GetNodes() []n.Node {
+	nodes := make([]n.Node, len(cg.nodes))
+	copy(nodes, cg.nodes)
+	return nodes
+}
+
+// HasNode returns true if the graph contains the specified node
+func (cg *CompactGraph) HasNode(node n.Node) bool {
+	_, ok := cg.ids[node]
+	return ok
+}
+
+// HasEdge returns true if an edge exists from src to tgt
+func (cg *CompactGraph) HasEdge(src n.Node, tgt n.Node) bool {
+	_, ok := cg.GetEdgeWeight(src, tgt)
+	return ok
+}
+
+// GetEdgeWeight gets the weight of the edge from src to tgt
+func (cg *CompactGraph) GetEdgeWeight(src n.Node, tgt n.Node) (float64, bool) {
+	srcID, srcOk := cg.ids[src]
+	tgtID, tgtOk := cg.ids[tgt]
+	if !srcOk || !tgtOk {
+		return 0, false
+	}
+	k, ok := cg.out.find(srcID, tgtID)
+	if !ok {
+		return 0, false
+	}
+	return cg.out.wgts[k], true
+}
+
+// GetNeighbors gets a map of the nodes that a node has an edge to and the weights of the edges, which is
+// built on each call; RangeNeighbors or Neighbors avoid this allocation
+func (cg *CompactGraph) GetNeighbors(node n.Node) (map[n.Node]float64, bool) {
+	return cg.neighborMap(cg.out, node)
+}
+
+// GetInvNeighbors gets a map of the nodes that have an edge to a node and the weights of the edges,
+// which is built on each call
+func (cg *CompactGraph) GetInvNeighbors(node n.Node) (map[n.Node]float64, bool) {
+	return cg.neighborMap(cg.in, node)
+}
+
+// lookup gets the id of a node if it was found when building the edges of c
+func (cg *CompactGraph) lookup(c *csr, node n.Node) (int, bool) {
+	id, ok := cg.ids[node]
+	return id, ok && c.found[id]
+}
+
+func (cg *CompactGraph) neighborMap(c *csr, node n.Node) (map[n.Node]float64, bool) {
+	id, ok := cg.lookup(c, node)
+	if !ok {
+		return nil, false
+	}
+	tgts, wgts := c.row(id)
+	nbrs := make(map[n.Node]float64, len(tgts))
+	for k, tgt := range tgts {
+		nbrs[cg.nodes[tgt]] = wgts[k]
+	}
+	return nbrs, true
+}
+
+// RangeNeighbors calls f for each node that a node has an edge to and the weight of the edge in order
+// of id, stopping if f returns false
+func (cg *CompactGraph) RangeNeighbors(node n.Node, f func(nbr n.Node, wgt float64) bool) bool {
+	id, ok := cg.lookup(cg.out, node)
+	if !ok {
+		return false
+	}
+	tgts, wgts := cg.out.row(id)
+	for k, tgt := range tgts {
+		if !f(cg.nodes[tgt], wgts[k]) {
+			break
+		}
+	}
+	return true
+}
+
+// GetOutDegree calculates the sum of weights of all edges with node as the source node
+func (cg *CompactGraph) GetOutDegree(node n.Node) (float64, bool) {
+	id, ok := cg.lookup(cg.out, node)
+	if !ok {
+		return 0, false
+	}
+	return cg.out.degree(id), true
+}
+
+// GetInDegree calculates the sum of weights of all edges with node as the target node
+func (cg *CompactGraph) GetInDegree(node n.Node) (float64, bool) {
+	id, ok := cg.lookup(cg.in, node)
+	if !ok {
+		return 0, false
+	}
+	return cg.in.degree(id), true
+}
+
+// GetTotalDegree calculates the sum of weights of all edges of a node, which for an undirected
+// graph is the sum of weights of all edges with node as the source node
+func (cg *CompactGraph) GetTotalDegree(node n.Node) (float64, bool) {
+	id, ok := cg.ids[node]
+	if !ok {
+		return 0, false
+	}
+	if !cg.directed {
+		return cg.out.degree(id), true
+	}
+	deg := cg.out.degree(id) + cg.in.degree(id)
+	// a self loop is both an outgoing and an incoming edge so remove its weight once
+	if k, ok := cg.out.find(id, id); ok {
+		deg -= cg.out.wgts[k]
+	}
+	return deg, true
+}
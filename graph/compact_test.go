@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// assertSameGraph checks that a CompactGraph answers every query the same as the graph it was built from
+func assertSameGraph(t *testing.T, g WeightedGraph, inv func(n.Node) (map[n.Node]float64, bool), cg *CompactGraph) {
+	assert.Equal(t, g.Directed(), cg.Directed())
+	assert.ElementsMatch(t, g.GetNodes(), cg.GetNodes())
+	assert.False(t, cg.HasNode("x"))
+
+	nodes := g.GetNodes()
+	for _, src := range nodes {
+		assert.True(t, cg.HasNode(src))
+
+		nbrs, ok := g.GetNeighbors(src)
+		cgNbrs, cgOk := cg.GetNeighbors(src)
+		assert.Equal(t, ok, cgOk, "neighbors of %s", src)
+		assert.Equal(t, len(nbrs), len(cgNbrs))
+		for nbr, wgt := range nbrs {
+			assert.Equal(t, wgt, cgNbrs[nbr])
+		}
+
+		invNbrs, ok := inv(src)
+		cgInvNbrs, cgOk := cg.GetInvNeighbors(src)
+		assert.Equal(t, ok, cgOk, "inverse neighbors of %s", src)
+		assert.Equal(t, len(invNbrs), len(cgInvNbrs))
+		for nbr, wgt := range invNbrs {
+			assert.Equal(t, wgt, cgInvNbrs[nbr])
+		}
+
+		for _, tgt := range nodes {
+			wgt, ok := g.GetEdgeWeight(src, tgt)
+			cgWgt, cgOk := cg.GetEdgeWeight(src, tgt)
+			assert.Equal(t, ok, cgOk, "edge %s %s", src, tgt)
+			assert.Equal(t, wgt, cgWgt, "edge %s %s", src, tgt)
+			assert.Equal(t, g.HasEdge(src, tgt), cg.HasEdge(src, tgt))
+		}
+
+		outDeg, ok := g.GetOutDegree(src)
+		cgOutDeg, cgOk := cg.GetOutDegree(src)
+		assert.Equal(t, ok, cgOk, "out degree of %s", src)
+		assert.InDelta(t, outDeg, cgOutDeg, 1e-12)
+		inDeg, ok := g.GetInDegree(src)
+		cgInDeg, cgOk := cg.GetInDegree(src)
+		assert.Equal(t, ok, cgOk, "in degree of %s", src)
+		assert.InDelta(t, inDeg, cgInDeg, 1e-12)
+		totalDeg, ok := g.GetTotalDegree(src)
+		cgTotalDeg, cgOk := cg.GetTotalDegree(src)
+		assert.Equal(t, ok, cgOk, "total degree of %s", src)
+		assert.InDelta(t, totalDeg, cgTotalDeg, 1e-12)
+
+		found := RangeNeighbors[n.Node](g, src, func(n.Node, float64) bool { return true })
+		assert.Equal(t, found, cg.RangeNeighbors(src, func(n.Node, float64) bool { return true }))
+	}
+}
+
+func TestCompact(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		g := setupTestGraph().g
+		g.AddNode("isolated")
+		cg := g.Compact()
+		assert.Equal(t, g.Name, cg.Name)
+		assert.Equal(t, 5, cg.NumNodes())
+		assertSameGraph(t, g, g.GetInvNeighbors, cg)
+	})
+	t.Run("directed", func(t *testing.T) {
+		dg := setupTestDirGraph().dg
+		dg.AddEdge("d", "sink", 2)
+		dg.AddNode("isolated")
+		cg := dg.Compact()
+		assert.Equal(t, dg.Name, cg.Name)
+		assert.Equal(t, 8, cg.NumEdges())
+		_, ok := cg.GetNeighbors("sink")
+		assert.False(t, ok)
+		assertSameGraph(t, dg, dg.GetInvNeighbors, cg)
+	})
+	t.Run("empty", func(t *testing.T) {
+		g, _ := NewGraph("empty")
+		cg := g.Compact()
+		assert.Equal(t, 0, cg.NumNodes())
+		assert.Equal(t, 0, cg.NumEdges())
+		assert.Empty(t, cg.GetNodes())
+		_, ok := cg.GetNeighbors("a")
+		assert.False(t, ok)
+		_, ok = cg.GetTotalDegree("a")
+		assert.False(t, ok)
+	})
+	t.Run("independent of source graph", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		cg := dg.Compact()
+		dg.AddEdge("b", "c")
+		dg.RemoveEdge("a", "b")
+		assert.True(t, cg.HasEdge("a", "b"))
+		assert.False(t, cg.HasNode("c"))
+	})
+}
+
+func TestCompactGraphIDs(t *testing.T) {
+	dg, _ := NewDirGraph("test")
+	dg.AddEdge("c", "a", 3)
+	dg.AddEdge("c", "b", 2)
+	dg.AddEdge("a", "c", 1)
+	cg := dg.Compact()
+
+	// ids are assigned in sorted order of nodes
+	for id, node := range []n.Node{"a", "b", "c"} {
+		gotID, ok := cg.ID(node)
+		assert.True(t, ok)
+		assert.Equal(t, id, gotID)
+		gotNode, ok := cg.Node(id)
+		assert.True(t, ok)
+		assert.Equal(t, node, gotNode)
+	}
+	_, ok := cg.ID("x")
+	assert.False(t, ok)
+	_, ok = cg.Node(-1)
+	assert.False(t, ok)
+	_, ok = cg.Node(3)
+	assert.False(t, ok)
+
+	tgts, wgts := cg.Neighbors(2)
+	assert.Equal(t, []int{0, 1}, tgts)
+	assert.Equal(t, []float64{3, 2}, wgts)
+	tgts, wgts = cg.InvNeighbors(0)
+	assert.Equal(t, []int{2}, tgts)
+	assert.Equal(t, []float64{3}, wgts)
+	tgts, _ = cg.Neighbors(1)
+	assert.Empty(t, tgts)
+}
+
+func TestCompactGraphRangeNeighbors(t *testing.T) {
+	g, _ := NewGraph("test")
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("b", "a", 1)
+	g.AddEdge("b", "d", 3)
+	cg := g.Compact()
+
+	visited := []n.Node{}
+	found := cg.RangeNeighbors("b", func(nbr n.Node, wgt float64) bool {
+		visited = append(visited, nbr)
+		return nbr != "c"
+	})
+	assert.True(t, found)
+	assert.Equal(t, []n.Node{"a", "c"}, visited)
+
+	found = cg.RangeNeighbors("x", func(n.Node, float64) bool { return true })
+	assert.False(t, found)
+}
+
+func TestRangeNeighbors(t *testing.T) {
+	g := setupTestGraph().g
+	for name, rg := range map[string]ReadOnlyGraph{
+		"graph":         g,
+		"compact graph": g.Compact(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			nbrs := map[n.Node]float64{}
+			found := RangeNeighbors(rg, "a", func(nbr n.Node, wgt float64) bool {
+				nbrs[nbr] = wgt
+				return true
+			})
+			assert.True(t, found)
+			expected, _ := g.GetNeighbors("a")
+			assert.Equal(t, expected, nbrs)
+
+			count := 0
+			RangeNeighbors(rg, "a", func(n.Node, float64) bool {
+				count++
+				return false
+			})
+			assert.Equal(t, 1, count)
+
+			assert.False(t, RangeNeighbors(rg, "x", func(n.Node, float64) bool { return true }))
+		})
+	}
+}
+
+func BenchmarkGetEdgeWeight(b *testing.B) {
+	const numNodes = 10000
+	nodes := make([]n.Node, numNodes)
+	for i := range nodes {
+		nodes[i] = n.Node(fmt.Sprint(i))
+	}
+	dg, _ := NewDirGraph("bench")
+	for i := range nodes {
+		for j := 1; j <= 10; j++ {
+			dg.AddEdge(nodes[i], nodes[(i*j+7)%numNodes], float64(j))
+		}
+	}
+
+	benchGraphs := map[string]WeightedGraph{"map": dg, "compact": dg.Compact()}
+	for _, name := range []string{"map", "compact"} {
+		g := benchGraphs[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.GetEdgeWeight(nodes[i%numNodes], nodes[7])
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package graph
+
+import (
+	"io"
+	"sync"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// lockedGraph guards the methods of a graph with a read/write lock, returning copies
+// of neighbor and attribute maps rather than the maps held by the graph
+type lockedGraph struct {
+	mu sync.RWMutex
+	g  fullGraph
+}
+
+// Directed returns true if the graph is directed
+func (lg *lockedGraph) Directed() bool {
+	return lg.g.Directed()
+}
+
+// AddNode adds a node without any edges if the node does not already exist
+func (lg *lockedGraph) AddNode(node n.Node) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.AddNode(node)
+}
+
+// AddEdge adds an edge with an optional weight that defaults to 1.0
+func (lg *lockedGraph) AddEdge(src n.Node, tgt n.Node, weight ...float64) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.AddEdge(src, tgt, weight...)
+}
+
+// RemoveEdge removes an edge, keeping both nodes in the graph
+func (lg *lockedGraph) RemoveEdge(src n.Node, tgt n.Node) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.RemoveEdge(src, tgt)
+}
+
+// RemoveNode removes a node entirely such that no edges exist between it and any other node
+func (lg *lockedGraph) RemoveNode(node n.Node) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.RemoveNode(node)
+}
+
+// GetNodes gets a slice of all nodes
+func (lg *lockedGraph) GetNodes() []n.Node {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.GetNodes()
+}
+
+// HasNode returns true if the graph contains the specified node
+func (lg *lockedGraph) HasNode(node n.Node) bool {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.HasNode(node)
+}
+
+// HasEdge returns true if an edge exists from src to tgt
+func (lg *lockedGraph) HasEdge(src n.Node, tgt n.Node) bool {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.HasEdge(src, tgt)
+}
+
+// GetEdgeWeight gets the weight of the edge from src to tgt
+func (lg *lockedGraph) GetEdgeWeight(src n.Node, tgt n.Node) (float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.GetEdgeWeight(src, tgt)
+}
+
+// GetNeighbors gets a copy of the map of the nodes that a node has an edge to and the weights of the edges
+func (lg *lockedGraph) GetNeighbors(node n.Node) (map[n.Node]float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return copyNeighbors(lg.g.GetNeighbors(node))
+}
+
+// GetInvNeighbors gets a copy of the map of the nodes that have an edge to a node and the weights of the edges
+func (lg *lockedGraph) GetInvNeighbors(node n.Node) (map[n.Node]float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return copyNeighbors(lg.g.GetInvNeighbors(node))
+}
+
+// RangeNeighbors calls f for each node that a node has an edge to and the weight of the edge, stopping
+// if f returns false, without copying the neighbors; the read lock is held while f runs so f must not
+// call any method of the graph, since a second read lock deadlocks if a writer is waiting
+func (lg *lockedGraph) RangeNeighbors(node n.Node, f func(nbr n.Node, wgt float64) bool) bool {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	nbrs, ok := lg.g.GetNeighbors(node)
+	for nbr, wgt := range nbrs {
+		if !f(nbr, wgt) {
+			break
+		}
+	}
+	return ok
+}
+
+// GetOutDegree calculates the sum of weights of all edges with node as the source node
+func (lg *lockedGraph) GetOutDegree(node n.Node) (float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.GetOutDegree(node)
+}
+
+// GetInDegree calculates the sum of weights of all edges with node as the target node
+func (lg *lockedGraph) GetInDegree(node n.Node) (float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.GetInDegree(node)
+}
+
+// GetTotalDegree calculates the sum of weights of all edges of a node
+func (lg *lockedGraph) GetTotalDegree(node n.Node) (float64, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.GetTotalDegree(node)
+}
+
+// SetNodeAttr sets an attribute of a node, adding the node if it does not already exist
+func (lg *lockedGraph) SetNodeAttr(node n.Node, key string, value interface{}) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.SetNodeAttr(node, key, value)
+}
+
+// GetNodeAttrs gets a copy of the attributes of a node
+func (lg *lockedGraph) GetNodeAttrs(node n.Node) (Attributes, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return copyAttrs(lg.g.GetNodeAttrs(node))
+}
+
+// DeleteNodeAttr removes an attribute from a node
+func (lg *lockedGraph) DeleteNodeAttr(node n.Node, key string) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.DeleteNodeAttr(node, key)
+}
+
+// SetEdgeAttr sets an attribute of an existing edge, returning false if the edge does not exist
+func (lg *lockedGraph) SetEdgeAttr(src n.Node, tgt n.Node, key string, value interface{}) bool {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	return lg.g.SetEdgeAttr(src, tgt, key, value)
+}
+
+// GetEdgeAttrs gets a copy of the attributes of an edge
+func (lg *lockedGraph) GetEdgeAttrs(src n.Node, tgt n.Node) (Attributes, bool) {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return copyAttrs(lg.g.GetEdgeAttrs(src, tgt))
+}
+
+// DeleteEdgeAttr removes an attribute from an edge
+func (lg *lockedGraph) DeleteEdgeAttr(src n.Node, tgt n.Node, key string) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.g.DeleteEdgeAttr(src, tgt, key)
+}
+
+// WriteEdgeList writes the graph in the edge list format
+func (lg *lockedGraph) WriteEdgeList(w io.Writer) error {
+	lg.mu.RLock()
+	defer lg.mu.RUnlock()
+	return lg.g.WriteEdgeList(w)
+}
+
+func copyNeighbors(nbrs map[n.Node]float64, ok bool) (map[n.Node]float64, bool) {
+	if !ok {
+		return nbrs, ok
+	}
+	cp := make(map[n.Node]float64, len(nbrs))
+	for nbr, wgt := range nbrs {
+		cp[nbr] = wgt
+	}
+	return cp, true
+}
+
+func copyAttrs(attrs Attributes, ok bool) (Attributes, bool) {
+	if !ok {
+		return attrs, ok
+	}
+	cp := make(Attributes, len(attrs))
+	for key, val := range attrs {
+		cp[key] = val
+	}
+	return cp, true
+}
+
+// ConcurrentGraph is an undirected graph that is safe for concurrent use by multiple goroutines
+type ConcurrentGraph struct {
+	lockedGraph
+	ug *Graph
+}
+
+// NewConcurrentGraph creates a new undirected graph that is safe for concurrent use
+func NewConcurrentGraph(name string, readers ...io.ReadCloser) (*ConcurrentGraph, error) {
+	g, err := NewGraph(name, readers...)
+	cg := &ConcurrentGraph{
+		lockedGraph: lockedGraph{g: g},
+		ug:          g,
+	}
+	return cg, err
+}
+
+// Name gets the name of the graph
+func (cg *ConcurrentGraph) Name() string {
+	cg.mu.RLock()
+	defer cg.mu.RUnlock()
+	return cg.ug.Name
+}
+
+// GetDegree calculates the sum of weights of all edges with node as the source node
+func (cg *ConcurrentGraph) GetDegree(node n.Node) (float64, bool) {
+	return cg.GetOutDegree(node)
+}
+
+// View calls f with the underlying graph while holding the read lock so that algorithms can run on a
+// consistent state of the graph; f must not modify the graph or call other methods of the ConcurrentGraph
+func (cg *ConcurrentGraph) View(f func(g *Graph)) {
+	cg.mu.RLock()
+	defer cg.mu.RUnlock()
+	f(cg.ug)
+}
+
+// Update calls f with the underlying graph while holding the write lock so that multiple modifications
+// are applied atomically; f must not call other methods of the ConcurrentGraph
+func (cg *ConcurrentGraph) Update(f func(g *Graph)) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	f(cg.ug)
+}
+
+// ConcurrentDirGraph is a directed graph that is safe for concurrent use by multiple goroutines
+type ConcurrentDirGraph struct {
+	lockedGraph
+	dg *DirGraph
+}
+
+// NewConcurrentDirGraph creates a new directed graph that is safe for concurrent use
+func NewConcurrentDirGraph(name string, readers ...io.ReadCloser) (*ConcurrentDirGraph, error) {
+	dg, err := NewDirGraph(name, readers...)
+	cdg := &ConcurrentDirGraph{
+		lockedGraph: lockedGraph{g: dg},
+		dg:          dg,
+	}
+	return cdg, err
+}
+
+// Name gets the name of the graph
+func (cdg *ConcurrentDirGraph) Name() string {
+	cdg.mu.RLock()
+	defer cdg.mu.RUnlock()
+	return cdg.dg.Name
+}
+
+// View calls f with the underlying graph while holding the read lock so that algorithms can run on a
+// consistent state of the graph; f must not modify the graph or call other methods of the ConcurrentDirGraph
+func (cdg *ConcurrentDirGraph) View(f func(dg *DirGraph)) {
+	cdg.mu.RLock()
+	defer cdg.mu.RUnlock()
+	f(cdg.dg)
+}
+
+// Update calls f with the underlying graph while holding the write lock so that multiple modifications
+// are applied atomically; f must not call other methods of the ConcurrentDirGraph
+func (cdg *ConcurrentDirGraph) Update(f func(dg *DirGraph)) {
+	cdg.mu.Lock()
+	defer cdg.mu.Unlock()
+	f(cdg.dg)
+}
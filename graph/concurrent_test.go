@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+const (
+	numWorkers   = 16
+	opsPerWorker = 200
+)
+
+func TestNewConcurrentGraph(t *testing.T) {
+	t.Run("undirected from reader", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b 1.5\nb c")))
+		cg, err := NewConcurrentGraph("test", reader)
+		assert.Nil(t, err)
+		assert.Equal(t, "test", cg.Name())
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c"}, cg.GetNodes())
+		deg, _ := cg.GetDegree("b")
+		assert.Equal(t, 2.5, deg)
+	})
+	t.Run("directed from reader", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b 1.5\nb c")))
+		cdg, err := NewConcurrentDirGraph("test", reader)
+		assert.Nil(t, err)
+		assert.Equal(t, "test", cdg.Name())
+		assert.True(t, cdg.HasEdge("a", "b"))
+		assert.False(t, cdg.HasEdge("b", "a"))
+		deg, _ := cdg.GetTotalDegree("b")
+		assert.Equal(t, 2.5, deg)
+	})
+	t.Run("reader error", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b x")))
+		_, err := NewConcurrentGraph("test", reader)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestConcurrentGraphReturnsCopies(t *testing.T) {
+	cg, _ := NewConcurrentGraph("test")
+	cg.AddEdge("a", "b", 2)
+	cg.SetNodeAttr("a", "label", "x")
+	cg.SetEdgeAttr("a", "b", "label", "y")
+
+	nbrs, ok := cg.GetNeighbors("a")
+	assert.True(t, ok)
+	nbrs["c"] = 1
+	delete(nbrs, "b")
+	assert.True(t, cg.HasEdge("a", "b"))
+	assert.False(t, cg.HasEdge("a", "c"))
+
+	invNbrs, _ := cg.GetInvNeighbors("a")
+	invNbrs["c"] = 1
+	assert.False(t, cg.HasEdge("c", "a"))
+
+	attrs, _ := cg.GetNodeAttrs("a")
+	attrs["label"] = "z"
+	attrs, _ = cg.GetNodeAttrs("a")
+	assert.Equal(t, Attributes{"label": "x"}, attrs)
+
+	attrs, _ = cg.GetEdgeAttrs("b", "a")
+	attrs["label"] = "z"
+	attrs, _ = cg.GetEdgeAttrs("a", "b")
+	assert.Equal(t, Attributes{"label": "y"}, attrs)
+
+	_, ok = cg.GetNeighbors("x")
+	assert.False(t, ok)
+	_, ok = cg.GetNodeAttrs("x")
+	assert.False(t, ok)
+}
+
+func TestConcurrentGraphRangeNeighbors(t *testing.T) {
+	cdg, _ := NewConcurrentDirGraph("test")
+	cdg.AddEdge("a", "b", 1)
+	cdg.AddEdge("a", "c", 2)
+
+	total := 0.0
+	ok := cdg.RangeNeighbors("a", func(nbr n.Node, wgt float64) bool {
+		total += wgt
+		return true
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, total)
+
+	calls := 0
+	cdg.RangeNeighbors("a", func(nbr n.Node, wgt float64) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls)
+
+	assert.False(t, cdg.RangeNeighbors("x", func(n.Node, float64) bool { return true }))
+}
+
+func TestConcurrentGraphViewUpdate(t *testing.T) {
+	cdg, _ := NewConcurrentDirGraph("test")
+	cdg.Update(func(dg *DirGraph) {
+		dg.AddEdge("a", "b")
+		dg.AddEdge("b", "c")
+		dg.Name = "renamed"
+	})
+	assert.Equal(t, "renamed", cdg.Name())
+
+	var nodes []n.Node
+	cdg.View(func(dg *DirGraph) {
+		nodes = dg.GetNodes()
+	})
+	assert.ElementsMatch(t, []n.Node{"a", "b", "c"}, nodes)
+
+	cg, _ := NewConcurrentGraph("test")
+	cg.Update(func(g *Graph) { g.AddEdge("a", "b") })
+	cg.View(func(g *Graph) { assert.True(t, g.HasEdge("b", "a")) })
+}
+
+// hammer runs writers that add and remove edges, nodes and attributes concurrently with readers
+func hammer(t *testing.T, lg *lockedGraph) {
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < opsPerWorker; i++ {
+				src := n.Node(fmt.Sprint(w))
+				tgt := n.Node(fmt.Sprint(i % numWorkers))
+				lg.AddEdge(src, tgt, float64(i))
+				lg.SetNodeAttr(src, "op", i)
+				lg.SetEdgeAttr(src, tgt, "op", i)
+				if i%3 == 0 {
+					lg.RemoveEdge(src, tgt)
+				}
+				if i%50 == 0 {
+					lg.RemoveNode(tgt)
+				}
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < opsPerWorker; i++ {
+				node := n.Node(fmt.Sprint(i % numWorkers))
+				if nbrs, ok := lg.GetNeighbors(node); ok {
+					for nbr := range nbrs {
+						lg.GetEdgeWeight(node, nbr)
+						lg.GetEdgeAttrs(node, nbr)
+					}
+				}
+				lg.GetInvNeighbors(node)
+				lg.RangeNeighbors(node, func(n.Node, float64) bool { return true })
+				lg.GetNodes()
+				lg.GetTotalDegree(node)
+				lg.GetInDegree(node)
+				lg.GetNodeAttrs(node)
+				lg.HasEdge(node, n.Node(fmt.Sprint(w)))
+				lg.WriteEdgeList(ioutil.Discard)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentGraphRace(t *testing.T) {
+	cg, _ := NewConcurrentGraph("test")
+	hammer(t, &cg.lockedGraph)
+
+	// the adjacency must remain symmetric after concurrent modification
+	cg.View(func(g *Graph) {
+		for _, src := range g.GetNodes() {
+			nbrs, _ := g.GetNeighbors(src)
+			for tgt, wgt := range nbrs {
+				invWgt, ok := g.GetEdgeWeight(tgt, src)
+				assert.True(t, ok)
+				assert.Equal(t, wgt, invWgt)
+			}
+		}
+	})
+}
+
+func TestConcurrentDirGraphRace(t *testing.T) {
+	cdg, _ := NewConcurrentDirGraph("test")
+	hammer(t, &cdg.lockedGraph)
+
+	// the inverse adjacency must remain consistent after concurrent modification
+	cdg.View(func(dg *DirGraph) {
+		for _, src := range dg.GetNodes() {
+			nbrs, _ := dg.GetNeighbors(src)
+			for tgt, wgt := range nbrs {
+				invNbrs, _ := dg.GetInvNeighbors(tgt)
+				assert.Equal(t, wgt, invNbrs[src])
+			}
+		}
+	})
+}
@@ -6,59 +6,108 @@ import (
 	n "github.com/dkaslovsky/GoGraph/node"
 )
 
-// DirGraph is an adjacency map representation of a directed graph
+// DirGraphOf is an adjacency map representation of a directed graph with nodes of any comparable type
+type DirGraphOf[T comparable] struct {
+	GraphOf[T]
+}
+
+// DirGraph is an adjacency map representation of a directed graph with string nodes,
+// which also holds node and edge attributes and can be read and written in text formats
 type DirGraph struct {
 	Graph
 }
 
+// NewDirGraphOf creates a new directed graph with nodes of any comparable type
+func NewDirGraphOf[T comparable](name string) *DirGraphOf[T] {
+	return &DirGraphOf[T]{
+		GraphOf[T]{
+			dirAdj: &dirAdj[T]{},
+			Name:   name,
+			invAdj: &dirAdj[T]{},
+		},
+	}
+}
+
 // NewDirGraph creates a new directed graph
 func NewDirGraph(name string, readers ...io.ReadCloser) (*DirGraph, error) {
-	dg := &DirGraph{
+	dg, _, err := EdgeListReader{}.NewDirGraph(name, readers...)
+	return dg, err
+}
+
+func newDirGraph(name string) *DirGraph {
+	return &DirGraph{
 		Graph{
-			dirAdj: &dirAdj{},
-			Name:   name,
-			invAdj: &dirAdj{},
+			GraphOf:      NewDirGraphOf[n.Node](name).GraphOf,
+			nodeAttrs:    map[n.Node]Attributes{},
+			edgeAttrs:    &attrAdj{},
+			invEdgeAttrs: &attrAdj{},
 		},
 	}
-	for _, r := range readers {
-		err := dg.addFromReader(r)
-		if err != nil {
-			return dg, err
-		}
-	}
-	return dg, nil
+}
+
+// Directed returns true since a DirGraph is directed
+func (dg *DirGraphOf[T]) Directed() bool {
+	return true
+}
+
+// Directed returns true since a DirGraph is directed
+func (dg *DirGraph) Directed() bool {
+	return true
 }
 
 // RemoveNode removes a node entirely from a DirGraph such that
 // no edges exist between it an any other node
+func (dg *DirGraphOf[T]) RemoveNode(node T) {
+	dg.removeDirNode(node, dg.RemoveEdge)
+}
+
+// RemoveNode removes a node and its attributes entirely from a DirGraph such that
+// no edges exist between it an any other node
 func (dg *DirGraph) RemoveNode(node n.Node) {
+	dg.removeDirNode(node, dg.RemoveEdge)
+	delete(dg.nodeAttrs, node)
+}
+
+// removeDirNode removes a node of a directed graph using a function that removes each of its edges
+func (g *GraphOf[T]) removeDirNode(node T, removeEdge func(src T, tgt T)) {
 	// remove node from dirAdj
-	if nbrs, ok := dg.GetNeighbors(node); ok {
+	if nbrs, ok := g.GetNeighbors(node); ok {
 		for n := range nbrs {
-			dg.RemoveEdge(node, n)
+			removeEdge(node, n)
 		}
 	}
 	// also remove node from invAdj
-	if nbrs, ok := dg.GetInvNeighbors(node); ok {
+	if nbrs, ok := g.GetInvNeighbors(node); ok {
 		for n := range nbrs {
-			dg.RemoveEdge(n, node)
+			removeEdge(n, node)
 		}
 	}
+	g.removeSrcNode(node)
+	g.invAdj.removeSrcNode(node)
+}
+
+// GetNodes gets a slice of all nodes in a DirGraph
+func (dg *DirGraphOf[T]) GetNodes() []T {
+	return dg.getDirNodes()
 }
 
 // GetNodes gets a slice of all nodes in a DirGraph
 func (dg *DirGraph) GetNodes() []n.Node {
+	return dg.getDirNodes()
+}
+
+func (g *GraphOf[T]) getDirNodes() []T {
 
-	nodes := dg.getSrcNodes() // guaranteed to be unique
+	nodes := g.getSrcNodes() // guaranteed to be unique
 
 	// maintain map keyed by nodes to avoid adding duplicates from invAdj
-	nodeSet := n.NewSet()
+	nodeSet := n.NewSetOf[T]()
 	for _, node := range nodes {
 		nodeSet.Add(node)
 	}
 
 	// append invAdj node only if it is not in the set
-	invNodes := dg.invAdj.getSrcNodes() // guaranteed to be unique
+	invNodes := g.invAdj.getSrcNodes() // guaranteed to be unique
 	for _, node := range invNodes {
 		if !nodeSet.Contains(node) {
 			nodes = append(nodes, node)
@@ -69,25 +118,41 @@ func (dg *DirGraph) GetNodes() []n.Node {
 }
 
 // GetTotalDegree calculates the sum of weights of all edges from and to a node
-func (dg *DirGraph) GetTotalDegree(node n.Node) (deg float64, found bool) {
-	outDeg, ok := dg.GetOutDegree(node)
-	if !ok {
-		return deg, false
-	}
-	inDeg, ok := dg.GetInDegree(node)
-	if !ok {
+func (dg *DirGraphOf[T]) GetTotalDegree(node T) (float64, bool) {
+	return dg.getDirTotalDegree(node)
+}
+
+// GetTotalDegree calculates the sum of weights of all edges from and to a node
+func (dg *DirGraph) GetTotalDegree(node n.Node) (float64, bool) {
+	return dg.getDirTotalDegree(node)
+}
+
+func (g *GraphOf[T]) getDirTotalDegree(node T) (deg float64, found bool) {
+	if !g.hasDirNode(node) {
 		return deg, false
 	}
+	// a node with only incoming or only outgoing edges has zero degree in the other direction
+	outDeg, _ := g.GetOutDegree(node)
+	inDeg, _ := g.GetInDegree(node)
 	deg = inDeg + outDeg
 	// if a self loop exists its weight has been
 	// double counted so remove its weight once
-	if w, ok := dg.GetEdgeWeight(node, node); ok {
+	if w, ok := g.GetEdgeWeight(node, node); ok {
 		deg -= w
 	}
 	return deg, true
 }
 
+// HasNode returns true if the directed graph contains the specified node
+func (dg *DirGraphOf[T]) HasNode(node T) bool {
+	return dg.hasDirNode(node)
+}
+
 // HasNode returns true if the directed graph contains the specified node
 func (dg *DirGraph) HasNode(node n.Node) bool {
-	return dg.dirAdj.hasSrcNode(node) || dg.invAdj.hasSrcNode(node)
+	return dg.hasDirNode(node)
+}
+
+func (g *GraphOf[T]) hasDirNode(node T) bool {
+	return g.dirAdj.hasSrcNode(node) || g.invAdj.hasSrcNode(node)
 }
@@ -63,6 +63,68 @@ func TestNewDirGraph(t *testing.T) {
 	})
 }
 
+func TestNewDirGraphIsolatedNodes(t *testing.T) {
+	t.Run("graph from reader with isolated nodes", func(t *testing.T) {
+		f := []byte("a b\nc\n\nd\na")
+		reader := ioutil.NopCloser(bytes.NewReader(f))
+		dg, err := NewDirGraph("test", reader)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d"}, dg.GetNodes())
+		assert.True(t, dg.HasEdge("a", "b"))
+		assert.False(t, dg.HasEdge("b", "a"))
+		for _, node := range []n.Node{"c", "d"} {
+			nbrs, ok := dg.GetNeighbors(node)
+			assert.True(t, ok)
+			assert.Empty(t, nbrs)
+			invNbrs, ok := dg.GetInvNeighbors(node)
+			assert.True(t, ok)
+			assert.Empty(t, invNbrs)
+		}
+	})
+}
+
+func TestDirGraphAddNode(t *testing.T) {
+	tests := map[string]struct {
+		node            n.Node
+		expectedNbrs    []n.Node
+		expectedInvNbrs []n.Node
+	}{
+		"add new node": {
+			node:            "x",
+			expectedNbrs:    []n.Node{},
+			expectedInvNbrs: []n.Node{},
+		},
+		"add existing node": {
+			node:            "b",
+			expectedNbrs:    []n.Node{"c"},
+			expectedInvNbrs: []n.Node{"a"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tg := setupTestDirGraph()
+			dg := tg.dg
+			dg.AddNode(test.node)
+			assert.True(t, dg.HasNode(test.node))
+			assert.Contains(t, dg.GetNodes(), test.node)
+
+			nbrs, ok := dg.GetNeighbors(test.node)
+			assert.True(t, ok)
+			assert.Len(t, nbrs, len(test.expectedNbrs))
+			invNbrs, ok := dg.GetInvNeighbors(test.node)
+			assert.True(t, ok)
+			assert.Len(t, invNbrs, len(test.expectedInvNbrs))
+
+			deg, ok := dg.GetTotalDegree(test.node)
+			assert.True(t, ok)
+			if len(test.expectedNbrs)+len(test.expectedInvNbrs) == 0 {
+				assert.Zero(t, deg)
+			}
+		})
+	}
+}
+
 func TestDirGraphAddEdgeDefaultWeight(t *testing.T) {
 	tests := map[string]testEdge{
 		"add edge to new nodes": {
@@ -219,6 +281,18 @@ func TestDirGraphRemoveEdge(t *testing.T) {
 	}
 }
 
+func TestDirGraphRemoveEdgeKeepsNodes(t *testing.T) {
+	t.Run("nodes remain after their only edge is removed", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		dg.RemoveEdge("a", "b")
+		assert.ElementsMatch(t, []n.Node{"a", "b"}, dg.GetNodes())
+		assert.True(t, dg.HasNode("a"))
+		assert.True(t, dg.HasNode("b"))
+		assert.False(t, dg.HasEdge("a", "b"))
+	})
+}
+
 func TestDirGraphRemoveNode(t *testing.T) {
 	tests := map[string]struct {
 		node n.Node
@@ -232,13 +306,18 @@ func TestDirGraphRemoveNode(t *testing.T) {
 		"remove existing node with self loop": {
 			node: "d",
 		},
+		"remove isolated node": {
+			node: "e",
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			tg := setupTestDirGraph()
 			dg := tg.dg
+			dg.AddNode("e")
 			dg.RemoveNode(test.node)
+			assert.False(t, dg.HasNode(test.node))
 
 			assert.NotContains(t, *dg.dirAdj, test.node)
 			for _, nbrs := range *dg.dirAdj {
@@ -340,6 +419,34 @@ func TestDirGraphGetTotalDegree(t *testing.T) {
 	}
 }
 
+func TestDirGraphGetTotalDegreeOneDirection(t *testing.T) {
+	tests := map[string]struct {
+		node        n.Node
+		expectedDeg float64
+	}{
+		"node with only outgoing edges": {
+			node:        "a",
+			expectedDeg: 3.5,
+		},
+		"node with only incoming edges": {
+			node:        "c",
+			expectedDeg: 2.5,
+		},
+	}
+
+	dg, _ := NewDirGraph("test")
+	dg.AddEdge("a", "b", 1.5)
+	dg.AddEdge("a", "c", 2)
+	dg.AddEdge("b", "c", 0.5)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, ok := dg.GetTotalDegree(test.node)
+			assert.True(t, ok)
+			assert.InEpsilon(t, test.expectedDeg, d, float64EqualTol)
+		})
+	}
+}
+
 func TestDirGraphGetInDegree(t *testing.T) {
 	tests := map[string]struct {
 		node        n.Node
@@ -401,3 +508,28 @@ func TestDirGraphHasNode(t *testing.T) {
 		})
 	}
 }
+
+func TestDirGraphOf(t *testing.T) {
+	dg := NewDirGraphOf[int]("ints")
+	assert.Equal(t, "ints", dg.Name)
+	assert.True(t, dg.Directed())
+	dg.AddEdge(1, 2, 1.5)
+	dg.AddEdge(2, 3)
+	dg.AddEdge(3, 3, 2)
+
+	assert.ElementsMatch(t, []int{1, 2, 3}, dg.GetNodes())
+	assert.True(t, dg.HasEdge(1, 2))
+	assert.False(t, dg.HasEdge(2, 1))
+	// a node with only incoming edges is in the graph
+	assert.True(t, dg.HasNode(3))
+	deg, _ := dg.GetTotalDegree(2)
+	assert.Equal(t, 2.5, deg)
+	deg, _ = dg.GetTotalDegree(3)
+	assert.Equal(t, 3.0, deg)
+
+	dg.RemoveNode(2)
+	assert.ElementsMatch(t, []int{1, 3}, dg.GetNodes())
+	assert.False(t, dg.HasEdge(1, 2))
+	invNbrs, _ := dg.GetInvNeighbors(3)
+	assert.Equal(t, map[int]float64{3: 2}, invNbrs)
+}
@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// DOTOptions configures the attributes written with the statements of a graph in the DOT language
+type DOTOptions struct {
+	// GraphAttrs are attributes of the graph itself, such as rankdir
+	GraphAttrs map[string]string
+	// NodeAttrs returns the attributes of a node, such as label or color
+	NodeAttrs func(node n.Node) map[string]string
+	// EdgeAttrs returns the attributes of an edge, such as label, color or a penwidth derived from the weight,
+	// which override the weight or wgt attribute written for every edge
+	EdgeAttrs func(src n.Node, tgt n.Node, wgt float64) map[string]string
+	// Highlight is a set of nodes, such as the result of a search, to be written with HighlightAttrs
+	Highlight []n.Node
+	// HighlightPath is a sequence of nodes whose nodes and the edges between consecutive nodes are
+	// to be written with HighlightAttrs
+	HighlightPath []n.Node
+	// HighlightAttrs override the attributes of highlighted nodes and edges, DefaultHighlightAttrs is used if nil
+	HighlightAttrs map[string]string
+}
+
+// DefaultHighlightAttrs are the attributes applied to highlighted nodes and edges if none are specified
+var DefaultHighlightAttrs = map[string]string{"color": "red", "penwidth": "2"}
+
+const (
+	dotWeightKey = "weight"
+	// dotWgtKey holds an edge weight that Graphviz does not accept as a weight attribute
+	dotWgtKey = "wgt"
+)
+
+// dotWeightAttr gets the attribute of an edge weight, which is the Graphviz weight attribute if the weight
+// is a non-negative integer as required by the dot layout and the non-reserved wgt attribute otherwise
+func dotWeightAttr(wgt float64) (string, string) {
+	if wgt >= 0 && wgt <= math.MaxInt32 && wgt == math.Trunc(wgt) {
+		return dotWeightKey, strconv.FormatFloat(wgt, 'f', -1, 64)
+	}
+	return dotWgtKey, strconv.FormatFloat(wgt, 'g', -1, 64)
+}
+
+// WriteDOT writes a Graph as an undirected graph in the DOT language with each undirected edge written once
+// and its weight written as a weight attribute if it is a non-negative integer and as a wgt attribute
+// otherwise, returning an error if an ID or attribute cannot be represented in a DOT quoted string
+func (g *Graph) WriteDOT(w io.Writer, opts DOTOptions) error {
+	return g.writeDOT(w, g.GetNodes(), false, opts)
+}
+
+// WriteDOT writes a DirGraph as a digraph in the DOT language with the weight of each edge written as
+// a weight attribute if it is a non-negative integer and as a wgt attribute otherwise, returning an
+// error if an ID or attribute cannot be represented in a DOT quoted string
+func (dg *DirGraph) WriteDOT(w io.Writer, opts DOTOptions) error {
+	return dg.writeDOT(w, dg.GetNodes(), true, opts)
+}
+
+func (g *Graph) writeDOT(w io.Writer, nodes []n.Node, directed bool, opts DOTOptions) error {
+	graphType, edgeOp := "graph", "--"
+	if directed {
+		graphType, edgeOp = "digraph", "->"
+	}
+	highlightAttrs := opts.HighlightAttrs
+	if highlightAttrs == nil {
+		highlightAttrs = DefaultHighlightAttrs
+	}
+
+	highlightNodes := n.NewSet()
+	for _, nodes := range [][]n.Node{opts.Highlight, opts.HighlightPath} {
+		for _, node := range nodes {
+			highlightNodes.Add(node)
+		}
+	}
+	highlightEdges := map[edge]bool{}
+	for i := 1; i < len(opts.HighlightPath); i++ {
+		src, tgt := opts.HighlightPath[i-1], opts.HighlightPath[i]
+		if !directed && tgt < src {
+			src, tgt = tgt, src
+		}
+		highlightEdges[edge{src: src, tgt: tgt}] = true
+	}
+
+	bw := bufio.NewWriter(w)
+	name, err := quoteDOT(g.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(bw, "%s %s {\n", graphType, name)
+	if len(opts.GraphAttrs) > 0 {
+		graphAttrs, err := formatDOTAttrs(opts.GraphAttrs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "\tgraph%s;\n", graphAttrs)
+	}
+
+	sortNodes(nodes)
+	for _, node := range nodes {
+		attrs := map[string]string{}
+		if opts.NodeAttrs != nil {
+			mergeDOTAttrs(attrs, opts.NodeAttrs(node))
+		}
+		if highlightNodes.Contains(node) {
+			mergeDOTAttrs(attrs, highlightAttrs)
+		}
+		id, err := quoteDOT(string(node))
+		if err != nil {
+			return err
+		}
+		nodeAttrs, err := formatDOTAttrs(attrs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "\t%s%s;\n", id, nodeAttrs)
+	}
+
+	for _, e := range g.sortedEdges(nodes, directed) {
+		key, val := dotWeightAttr(e.wgt)
+		attrs := map[string]string{key: val}
+		if opts.EdgeAttrs != nil {
+			mergeDOTAttrs(attrs, opts.EdgeAttrs(e.src, e.tgt, e.wgt))
+		}
+		if highlightEdges[edge{src: e.src, tgt: e.tgt}] {
+			mergeDOTAttrs(attrs, highlightAttrs)
+		}
+		// nodes were already checked when they were written
+		src, _ := quoteDOT(string(e.src))
+		tgt, _ := quoteDOT(string(e.tgt))
+		edgeAttrs, err := formatDOTAttrs(attrs)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "\t%s %s %s%s;\n", src, edgeOp, tgt, edgeAttrs)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func mergeDOTAttrs(attrs map[string]string, other map[string]string) {
+	for key, val := range other {
+		attrs[key] = val
+	}
+}
+
+// formatDOTAttrs formats an attribute list sorted by key, which is empty if there are no attributes
+func formatDOTAttrs(attrs map[string]string) (string, error) {
+	if len(attrs) == 0 {
+		return "", nil
+	}
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		k, err := formatDOTKey(key)
+		if err != nil {
+			return "", err
+		}
+		v, err := quoteDOT(attrs[key])
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, k+"="+v)
+	}
+	return " [" + strings.Join(fields, ", ") + "]", nil
+}
+
+// quoteDOT formats an ID as a DOT quoted string, in which only double quotes need to be escaped; an
+// error is returned for an ID with a backslash at its end or before a newline, which would be read
+// as an escaped closing quote or a line continuation
+func quoteDOT(id string) (string, error) {
+	if strings.HasSuffix(id, `\`) || strings.Contains(id, "\\\n") {
+		return "", fmt.Errorf("DOT ID %q cannot end with a backslash or contain a backslash before a newline", id)
+	}
+	return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`, nil
+}
+
+// formatDOTKey formats an attribute key, quoting it only if it is not a DOT identifier
+func formatDOTKey(key string) (string, error) {
+	if key == "" {
+		return quoteDOT(key)
+	}
+	for i, r := range key {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return quoteDOT(key)
+		}
+	}
+	return key, nil
+}
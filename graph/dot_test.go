@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func TestWriteDOT(t *testing.T) {
+	t.Run("undirected graph writes each edge once", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("b", "a", 1.5)
+		g.AddEdge("b", "c")
+		g.AddNode("d")
+
+		var buf bytes.Buffer
+		err := g.WriteDOT(&buf, DOTOptions{})
+		assert.Nil(t, err)
+		expected := "graph \"test\" {\n" +
+			"\t\"a\";\n\t\"b\";\n\t\"c\";\n\t\"d\";\n" +
+			"\t\"a\" -- \"b\" [wgt=\"1.5\"];\n" +
+			"\t\"b\" -- \"c\" [weight=\"1\"];\n" +
+			"}\n"
+		assert.Equal(t, expected, buf.String())
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		dg, _ := NewDirGraph(`say "hi"`)
+		dg.AddEdge("b", "a", 1.5)
+		dg.AddEdge("a", "b", 2)
+
+		var buf bytes.Buffer
+		err := dg.WriteDOT(&buf, DOTOptions{})
+		assert.Nil(t, err)
+		expected := "digraph \"say \\\"hi\\\"\" {\n" +
+			"\t\"a\";\n\t\"b\";\n" +
+			"\t\"a\" -> \"b\" [weight=\"2\"];\n" +
+			"\t\"b\" -> \"a\" [wgt=\"1.5\"];\n" +
+			"}\n"
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestWriteDOTWeights(t *testing.T) {
+	tests := map[string]struct {
+		wgt      float64
+		expected string
+	}{
+		"integer":      {wgt: 3, expected: `[weight="3"]`},
+		"zero":         {wgt: 0, expected: `[weight="0"]`},
+		"large":        {wgt: 1e6, expected: `[weight="1000000"]`},
+		"non integer":  {wgt: 0.25, expected: `[wgt="0.25"]`},
+		"negative":     {wgt: -2, expected: `[wgt="-2"]`},
+		"out of range": {wgt: 1e20, expected: `[wgt="1e+20"]`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dg, _ := NewDirGraph("test")
+			dg.AddEdge("a", "b", test.wgt)
+			var buf bytes.Buffer
+			assert.Nil(t, dg.WriteDOT(&buf, DOTOptions{}))
+			assert.Contains(t, buf.String(), "\t\"a\" -> \"b\" "+test.expected+";\n")
+
+			rt, err := ReadDirDOT(&buf)
+			assert.Nil(t, err)
+			wgt, _ := rt.GetEdgeWeight("a", "b")
+			assert.Equal(t, test.wgt, wgt)
+		})
+	}
+}
+
+func TestWriteDOTOptions(t *testing.T) {
+	dg, _ := NewDirGraph("test")
+	dg.AddEdge("a", "b", 2)
+	dg.AddEdge("b", "c", 4)
+	dg.AddEdge("c", "a", 1)
+
+	opts := DOTOptions{
+		GraphAttrs: map[string]string{"rankdir": "LR"},
+		NodeAttrs: func(node n.Node) map[string]string {
+			return map[string]string{"label": "node " + string(node)}
+		},
+		EdgeAttrs: func(src n.Node, tgt n.Node, wgt float64) map[string]string {
+			return map[string]string{"penwidth": fmt.Sprint(wgt / 2), "color": "gray"}
+		},
+		HighlightPath: []n.Node{"a", "b", "c"},
+	}
+
+	var buf bytes.Buffer
+	err := dg.WriteDOT(&buf, opts)
+	assert.Nil(t, err)
+	expected := "digraph \"test\" {\n" +
+		"\tgraph [rankdir=\"LR\"];\n" +
+		"\t\"a\" [color=\"red\", label=\"node a\", penwidth=\"2\"];\n" +
+		"\t\"b\" [color=\"red\", label=\"node b\", penwidth=\"2\"];\n" +
+		"\t\"c\" [color=\"red\", label=\"node c\", penwidth=\"2\"];\n" +
+		"\t\"a\" -> \"b\" [color=\"red\", penwidth=\"2\", weight=\"2\"];\n" +
+		"\t\"b\" -> \"c\" [color=\"red\", penwidth=\"2\", weight=\"4\"];\n" +
+		"\t\"c\" -> \"a\" [color=\"gray\", penwidth=\"0.5\", weight=\"1\"];\n" +
+		"}\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteDOTHighlight(t *testing.T) {
+	t.Run("undirected path highlights edges in either direction", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+		g.AddEdge("b", "c")
+		g.AddEdge("c", "d")
+
+		opts := DOTOptions{
+			Highlight:      []n.Node{"d"},
+			HighlightPath:  []n.Node{"c", "b"},
+			HighlightAttrs: map[string]string{"style": "bold"},
+		}
+		var buf bytes.Buffer
+		err := g.WriteDOT(&buf, opts)
+		assert.Nil(t, err)
+		expected := "graph \"test\" {\n" +
+			"\t\"a\";\n" +
+			"\t\"b\" [style=\"bold\"];\n" +
+			"\t\"c\" [style=\"bold\"];\n" +
+			"\t\"d\" [style=\"bold\"];\n" +
+			"\t\"a\" -- \"b\" [weight=\"1\"];\n" +
+			"\t\"b\" -- \"c\" [style=\"bold\", weight=\"1\"];\n" +
+			"\t\"c\" -- \"d\" [weight=\"1\"];\n" +
+			"}\n"
+		assert.Equal(t, expected, buf.String())
+	})
+	t.Run("directed path does not highlight reversed edges", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("a", "b")
+		dg.AddEdge("b", "a")
+
+		var buf bytes.Buffer
+		err := dg.WriteDOT(&buf, DOTOptions{HighlightPath: []n.Node{"b", "a"}})
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "\t\"a\" -> \"b\" [weight=\"1\"];\n")
+		assert.Contains(t, buf.String(), "\t\"b\" -> \"a\" [color=\"red\", penwidth=\"2\", weight=\"1\"];\n")
+	})
+}
+
+func TestFormatDOTKey(t *testing.T) {
+	tests := map[string]string{
+		"label":    "label",
+		"_x1":      "_x1",
+		"1x":       `"1x"`,
+		"two word": `"two word"`,
+		"":         `""`,
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			formatted, err := formatDOTKey(key)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, formatted)
+		})
+	}
+}
+
+func TestWriteDOTBackslash(t *testing.T) {
+	t.Run("backslash inside an ID round trips", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge(`a\b`, `c\"d`)
+		var buf bytes.Buffer
+		err := dg.WriteDOT(&buf, DOTOptions{})
+		assert.Nil(t, err)
+		rt, err := ReadDirDOT(&buf)
+		assert.Nil(t, err)
+		assert.True(t, rt.HasEdge(`a\b`, `c\"d`))
+	})
+
+	tests := map[string]struct {
+		node n.Node
+		opts DOTOptions
+	}{
+		"node ending with backslash":         {node: `a\`},
+		"node with backslash before newline": {node: "a\\\nb"},
+		"attribute value ending with backslash": {
+			node: "a",
+			opts: DOTOptions{NodeAttrs: func(n.Node) map[string]string { return map[string]string{"label": `x\`} }},
+		},
+		"attribute key ending with backslash": {
+			node: "a",
+			opts: DOTOptions{GraphAttrs: map[string]string{`x\`: "y"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dg, _ := NewDirGraph("test")
+			dg.AddEdge(test.node, "z")
+			var buf bytes.Buffer
+			err := dg.WriteDOT(&buf, test.opts)
+			assert.NotNil(t, err)
+		})
+	}
+}
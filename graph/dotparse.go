@@ -0,0 +1,645 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// DOTSyntaxError is an error encountered while parsing the DOT language
+type DOTSyntaxError struct {
+	Line int // line number, starting from 1
+	Col  int // column number in runes, starting from 1
+	Msg  string
+}
+
+func (e *DOTSyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// ReadDOT parses an undirected graph in the DOT language into a Graph named by the graph ID, mapping
+// the "wgt" attribute written by WriteDOT, or otherwise the "weight" attribute, of edges to edge weights and all other node and edge attributes to string
+// attributes; graph attributes and ports are ignored and an error is returned for a digraph
+func ReadDOT(r io.Reader) (*Graph, error) {
+	g := newGraph("")
+	err := parseDOT(r, g, false)
+	return g, err
+}
+
+// ReadDirDOT parses a digraph in the DOT language into a DirGraph named by the graph ID, mapping
+// the "wgt" attribute written by WriteDOT, or otherwise the "weight" attribute, of edges to edge weights and all other node and edge attributes to string
+// attributes; graph attributes and ports are ignored and an error is returned for an undirected graph
+func ReadDirDOT(r io.Reader) (*DirGraph, error) {
+	dg := newDirGraph("")
+	err := parseDOT(r, &dg.Graph, true)
+	return dg, err
+}
+
+func parseDOT(r io.Reader, g *Graph, directed bool) error {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	p := &dotParser{
+		lex:      &dotLexer{src: []rune(string(src)), line: 1, col: 1},
+		g:        g,
+		directed: directed,
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	return p.parseGraph()
+}
+
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotQuotedID // quoted and HTML strings are IDs that are never keywords
+	dotLBrace
+	dotRBrace
+	dotLBracket
+	dotRBracket
+	dotEqual
+	dotSemicolon
+	dotComma
+	dotColon
+	dotPlus
+	dotEdgeOp
+)
+
+type dotToken struct {
+	kind dotTokenKind
+	text string
+	line int
+	col  int
+}
+
+func (t dotToken) isID() bool {
+	return t.kind == dotID || t.kind == dotQuotedID
+}
+
+func (t dotToken) isKeyword(keyword string) bool {
+	return t.kind == dotID && strings.EqualFold(t.text, keyword)
+}
+
+func (t dotToken) describe() string {
+	if t.kind == dotEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+type dotLexer struct {
+	src  []rune
+	pos  int
+	line int
+	col  int
+}
+
+func (l *dotLexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+func (l *dotLexer) advance() rune {
+	r := l.src[l.pos]
+	l.pos++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *dotLexer) errorf(line int, col int, format string, args ...interface{}) error {
+	return &DOTSyntaxError{Line: line, Col: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// skip advances past whitespace and comments
+func (l *dotLexer) skip() error {
+	atLineStart := l.col == 1
+	for l.pos < len(l.src) {
+		r := l.peek(0)
+		switch {
+		case r == '\n':
+			l.advance()
+			atLineStart = true
+		case unicode.IsSpace(r):
+			l.advance()
+		case r == '#' && atLineStart:
+			// lines beginning with # are preprocessor output and are discarded
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '/':
+			for l.pos < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '/' && l.peek(1) == '*':
+			line, col := l.line, l.col
+			l.advance()
+			l.advance()
+			for !(l.peek(0) == '*' && l.peek(1) == '/') {
+				if l.pos >= len(l.src) {
+					return l.errorf(line, col, "unterminated comment")
+				}
+				l.advance()
+			}
+			l.advance()
+			l.advance()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *dotLexer) next() (dotToken, error) {
+	if err := l.skip(); err != nil {
+		return dotToken{}, err
+	}
+	tok := dotToken{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		tok.kind = dotEOF
+		return tok, nil
+	}
+
+	punctuation := map[rune]dotTokenKind{
+		'{': dotLBrace, '}': dotRBrace, '[': dotLBracket, ']': dotRBracket,
+		'=': dotEqual, ';': dotSemicolon, ',': dotComma, ':': dotColon, '+': dotPlus,
+	}
+	r := l.peek(0)
+	if kind, ok := punctuation[r]; ok {
+		l.advance()
+		tok.kind, tok.text = kind, string(r)
+		return tok, nil
+	}
+
+	switch {
+	case r == '-' && (l.peek(1) == '-' || l.peek(1) == '>'):
+		tok.kind, tok.text = dotEdgeOp, string([]rune{l.advance(), l.advance()})
+	case r == '"':
+		text, err := l.quoted()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = dotQuotedID, text
+	case r == '<':
+		text, err := l.html()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = dotQuotedID, text
+	case r == '-' || r == '.' || unicode.IsDigit(r):
+		text, err := l.numeral()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind, tok.text = dotID, text
+	case isDOTIDStart(r):
+		start := l.pos
+		for l.pos < len(l.src) && (isDOTIDStart(l.peek(0)) || unicode.IsDigit(l.peek(0))) {
+			l.advance()
+		}
+		tok.kind, tok.text = dotID, string(l.src[start:l.pos])
+	default:
+		return tok, l.errorf(tok.line, tok.col, "unexpected character %q", r)
+	}
+	return tok, nil
+}
+
+func isDOTIDStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r >= 0x80
+}
+
+// quoted reads a double quoted string in which only an escaped double quote and an escaped newline
+// (a line continuation) are unescaped
+func (l *dotLexer) quoted() (string, error) {
+	line, col := l.line, l.col
+	l.advance()
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return "", l.errorf(line, col, "unterminated string")
+		}
+		r := l.advance()
+		switch {
+		case r == '"':
+			return sb.String(), nil
+		case r == '\\' && l.peek(0) == '"':
+			sb.WriteRune(l.advance())
+		case r == '\\' && l.peek(0) == '\n':
+			l.advance()
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
+
+// html reads an HTML string delimited by balanced angle brackets
+func (l *dotLexer) html() (string, error) {
+	line, col := l.line, l.col
+	l.advance()
+	start := l.pos
+	depth := 1
+	for {
+		if l.pos >= len(l.src) {
+			return "", l.errorf(line, col, "unterminated HTML string")
+		}
+		switch l.advance() {
+		case '<':
+			depth++
+		case '>':
+			depth--
+			if depth == 0 {
+				return string(l.src[start : l.pos-1]), nil
+			}
+		}
+	}
+}
+
+// numeral reads a number of the form [-]?(.[0-9]+ | [0-9]+(.[0-9]*)?)
+func (l *dotLexer) numeral() (string, error) {
+	line, col := l.line, l.col
+	start := l.pos
+	if l.peek(0) == '-' {
+		l.advance()
+	}
+	digits := 0
+	for unicode.IsDigit(l.peek(0)) {
+		l.advance()
+		digits++
+	}
+	if l.peek(0) == '.' {
+		l.advance()
+		for unicode.IsDigit(l.peek(0)) {
+			l.advance()
+			digits++
+		}
+	}
+	if digits == 0 {
+		return "", l.errorf(line, col, "invalid numeral %q", string(l.src[start:l.pos]))
+	}
+	return string(l.src[start:l.pos]), nil
+}
+
+// dotScope holds the default node and edge attributes set by attribute statements,
+// which apply to the nodes and edges created later in the same graph or subgraph
+type dotScope struct {
+	nodeDefaults map[string]string
+	edgeDefaults map[string]string
+}
+
+func (s dotScope) child() dotScope {
+	child := dotScope{nodeDefaults: map[string]string{}, edgeDefaults: map[string]string{}}
+	mergeDOTAttrs(child.nodeDefaults, s.nodeDefaults)
+	mergeDOTAttrs(child.edgeDefaults, s.edgeDefaults)
+	return child
+}
+
+type dotAttr struct {
+	key string
+	val dotToken
+}
+
+type dotParser struct {
+	lex      *dotLexer
+	tok      dotToken
+	g        *Graph
+	directed bool
+}
+
+func (p *dotParser) next() (err error) {
+	p.tok, err = p.lex.next()
+	return err
+}
+
+func (p *dotParser) errorf(tok dotToken, format string, args ...interface{}) error {
+	return p.lex.errorf(tok.line, tok.col, format, args...)
+}
+
+func (p *dotParser) expect(kind dotTokenKind, desc string) (dotToken, error) {
+	tok := p.tok
+	if tok.kind != kind {
+		return tok, p.errorf(tok, "expected %s, found %s", desc, tok.describe())
+	}
+	return tok, p.next()
+}
+
+// parseID parses an ID, concatenating quoted strings joined by '+'
+func (p *dotParser) parseID() (dotToken, error) {
+	tok := p.tok
+	if !tok.isID() {
+		return tok, p.errorf(tok, "expected ID, found %s", tok.describe())
+	}
+	if err := p.next(); err != nil {
+		return tok, err
+	}
+	for tok.kind == dotQuotedID && p.tok.kind == dotPlus {
+		if err := p.next(); err != nil {
+			return tok, err
+		}
+		if p.tok.kind != dotQuotedID {
+			return tok, p.errorf(p.tok, "expected quoted string after '+', found %s", p.tok.describe())
+		}
+		tok.text += p.tok.text
+		if err := p.next(); err != nil {
+			return tok, err
+		}
+	}
+	return tok, nil
+}
+
+// parseGraph parses: [strict] (graph | digraph) [ID] '{' stmt_list '}'
+func (p *dotParser) parseGraph() error {
+	if p.tok.isKeyword("strict") {
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.tok.isKeyword("graph") && p.directed:
+		return p.errorf(p.tok, "expected digraph, found undirected graph")
+	case p.tok.isKeyword("digraph") && !p.directed:
+		return p.errorf(p.tok, "expected undirected graph, found digraph")
+	case !p.tok.isKeyword("graph") && !p.tok.isKeyword("digraph"):
+		return p.errorf(p.tok, "expected graph or digraph, found %s", p.tok.describe())
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+
+	if p.tok.isID() {
+		name, err := p.parseID()
+		if err != nil {
+			return err
+		}
+		p.g.Name = name.text
+	}
+	if _, err := p.expect(dotLBrace, "'{'"); err != nil {
+		return err
+	}
+	if _, err := p.parseStmtList(dotScope{}.child()); err != nil {
+		return err
+	}
+	if _, err := p.expect(dotRBrace, "'}'"); err != nil {
+		return err
+	}
+	_, err := p.expect(dotEOF, "end of input")
+	return err
+}
+
+// parseStmtList parses statements until a closing brace, returning the nodes that appear in them
+func (p *dotParser) parseStmtList(scope dotScope) ([]n.Node, error) {
+	nodes := []n.Node{}
+	for p.tok.kind != dotRBrace && p.tok.kind != dotEOF {
+		stmtNodes, err := p.parseStmt(scope)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, stmtNodes...)
+		if p.tok.kind == dotSemicolon {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func (p *dotParser) parseStmt(scope dotScope) ([]n.Node, error) {
+	switch {
+	case p.tok.isKeyword("graph"):
+		// graph attributes are not stored
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		_, err := p.parseAttrList()
+		return nil, err
+	case p.tok.isKeyword("node"), p.tok.isKeyword("edge"):
+		defaults := scope.nodeDefaults
+		if p.tok.isKeyword("edge") {
+			defaults = scope.edgeDefaults
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		attrs, err := p.parseAttrList()
+		for _, attr := range attrs {
+			defaults[attr.key] = attr.val.text
+		}
+		return nil, err
+	}
+
+	var operand []n.Node
+	var err error
+	isSubgraph := p.tok.kind == dotLBrace || p.tok.isKeyword("subgraph")
+	if isSubgraph {
+		operand, err = p.parseSubgraph(scope)
+	} else {
+		var id dotToken
+		id, err = p.parseID()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind == dotEqual {
+			// an ID '=' ID statement is a graph attribute and is not stored
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			_, err = p.parseID()
+			return nil, err
+		}
+		if err := p.skipPort(); err != nil {
+			return nil, err
+		}
+		operand = []n.Node{n.Node(id.text)}
+		p.declareNode(operand[0], scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != dotEdgeOp {
+		if isSubgraph {
+			return operand, nil
+		}
+		// a node statement with attributes
+		attrs, err := p.parseAttrList()
+		for _, attr := range attrs {
+			p.g.SetNodeAttr(operand[0], attr.key, attr.val.text)
+		}
+		return operand, err
+	}
+	return p.parseEdgeChain(operand, scope)
+}
+
+// parseEdgeChain parses the edges following the first operand of an edge statement and their attributes,
+// which apply to all edges between each node of each operand and each node of the following operand
+func (p *dotParser) parseEdgeChain(first []n.Node, scope dotScope) ([]n.Node, error) {
+	nodes := append([]n.Node{}, first...)
+	operands := [][]n.Node{first}
+	for p.tok.kind == dotEdgeOp {
+		if p.directed && p.tok.text != "->" {
+			return nil, p.errorf(p.tok, "undirected edge %q in digraph", p.tok.text)
+		}
+		if !p.directed && p.tok.text != "--" {
+			return nil, p.errorf(p.tok, "directed edge %q in undirected graph", p.tok.text)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		var operand []n.Node
+		if p.tok.kind == dotLBrace || p.tok.isKeyword("subgraph") {
+			var err error
+			operand, err = p.parseSubgraph(scope)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			id, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.skipPort(); err != nil {
+				return nil, err
+			}
+			operand = []n.Node{n.Node(id.text)}
+			p.declareNode(operand[0], scope)
+		}
+		operands = append(operands, operand)
+		nodes = append(nodes, operand...)
+	}
+
+	attrs := []dotAttr{}
+	for key, val := range scope.edgeDefaults {
+		attrs = append(attrs, dotAttr{key: key, val: dotToken{text: val, line: p.tok.line, col: p.tok.col}})
+	}
+	stmtAttrs, err := p.parseAttrList()
+	if err != nil {
+		return nil, err
+	}
+	attrs = append(attrs, stmtAttrs...)
+
+	wgt := defaultWgt
+	wgtKey := ""
+	edgeAttrs := map[string]string{}
+	for _, attr := range attrs {
+		if attr.key != dotWeightKey && attr.key != dotWgtKey {
+			edgeAttrs[attr.key] = attr.val.text
+			continue
+		}
+		val, err := strconv.ParseFloat(attr.val.text, 64)
+		if err != nil {
+			return nil, p.errorf(attr.val, "invalid weight %q", attr.val.text)
+		}
+		// a wgt attribute takes precedence over a weight attribute used only for layout
+		if wgtKey != dotWgtKey || attr.key == dotWgtKey {
+			wgt, wgtKey = val, attr.key
+		}
+	}
+
+	for i := 1; i < len(operands); i++ {
+		for _, src := range operands[i-1] {
+			for _, tgt := range operands[i] {
+				p.g.AddEdge(src, tgt, wgt)
+				for key, val := range edgeAttrs {
+					p.g.SetEdgeAttr(src, tgt, key, val)
+				}
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// parseSubgraph parses: [subgraph [ID]] '{' stmt_list '}'
+func (p *dotParser) parseSubgraph(scope dotScope) ([]n.Node, error) {
+	if p.tok.isKeyword("subgraph") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.isID() {
+			if _, err := p.parseID(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := p.expect(dotLBrace, "'{'"); err != nil {
+		return nil, err
+	}
+	nodes, err := p.parseStmtList(scope.child())
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.expect(dotRBrace, "'}'")
+	return nodes, err
+}
+
+// parseAttrList parses any number of bracketed lists of key=value attributes separated by ',' or ';'
+func (p *dotParser) parseAttrList() ([]dotAttr, error) {
+	attrs := []dotAttr{}
+	for p.tok.kind == dotLBracket {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for p.tok.kind != dotRBracket {
+			key, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(dotEqual, "'='"); err != nil {
+				return nil, err
+			}
+			val, err := p.parseID()
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, dotAttr{key: key.text, val: val})
+			if p.tok.kind == dotComma || p.tok.kind == dotSemicolon {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	return attrs, nil
+}
+
+// skipPort skips the optional port and compass point of a node ID, which are not stored
+func (p *dotParser) skipPort() error {
+	for i := 0; i < 2 && p.tok.kind == dotColon; i++ {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if _, err := p.parseID(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// declareNode adds a node with the default node attributes of the scope if it does not already exist
+func (p *dotParser) declareNode(node n.Node, scope dotScope) {
+	if p.g.HasNode(node) {
+		return
+	}
+	p.g.AddNode(node)
+	for key, val := range scope.nodeDefaults {
+		p.g.SetNodeAttr(node, key, val)
+	}
+}
@@ -0,0 +1,194 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func TestReadDOT(t *testing.T) {
+	src := `# preprocessor line
+/* a block
+   comment */
+strict graph "my" + " graph" {
+	rankdir = LR; // a graph attribute
+	graph [bgcolor="white"]
+	node [shape=box]
+	a [label="A \"quoted\" label"]
+	a -- b -- c [weight=2.5, color=red]
+	edge [style=dashed]
+	c:port:n -- d
+	{e f} -- g
+	subgraph cluster {
+		node [shape=circle]
+		h -- i [weight=-1]
+	}
+	j [label=<<b>bold</b>>]
+}`
+	g, err := ReadDOT(strings.NewReader(src))
+	assert.Nil(t, err)
+	assert.Equal(t, "my graph", g.Name)
+	assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, g.GetNodes())
+
+	expected := []testEdge{
+		{"a", "b", 2.5}, {"b", "c", 2.5}, {"c", "d", 1}, {"e", "g", 1}, {"f", "g", 1}, {"h", "i", -1},
+	}
+	for _, e := range expected {
+		wgt, ok := g.GetEdgeWeight(e.tgt, e.src)
+		assert.True(t, ok)
+		assert.Equal(t, e.wgt, wgt)
+	}
+	assert.False(t, g.HasEdge("e", "f"))
+
+	attrs, _ := g.GetNodeAttrs("a")
+	assert.Equal(t, Attributes{"shape": "box", "label": `A "quoted" label`}, attrs)
+	attrs, _ = g.GetNodeAttrs("h")
+	assert.Equal(t, Attributes{"shape": "circle"}, attrs)
+	attrs, _ = g.GetNodeAttrs("j")
+	assert.Equal(t, Attributes{"shape": "box", "label": "<b>bold</b>"}, attrs)
+	attrs, _ = g.GetEdgeAttrs("b", "a")
+	assert.Equal(t, Attributes{"color": "red"}, attrs)
+	attrs, _ = g.GetEdgeAttrs("c", "d")
+	assert.Equal(t, Attributes{"style": "dashed"}, attrs)
+}
+
+func TestReadDirDOT(t *testing.T) {
+	src := `digraph {
+		a -> {b c} -> d
+		d -> a [weight=.5]
+		e
+	}`
+	dg, err := ReadDirDOT(strings.NewReader(src))
+	assert.Nil(t, err)
+	assert.Equal(t, "", dg.Name)
+	assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d", "e"}, dg.GetNodes())
+	for _, e := range []testEdge{{"a", "b", 1}, {"a", "c", 1}, {"b", "d", 1}, {"c", "d", 1}, {"d", "a", 0.5}} {
+		wgt, ok := dg.GetEdgeWeight(e.src, e.tgt)
+		assert.True(t, ok)
+		assert.Equal(t, e.wgt, wgt)
+	}
+	assert.False(t, dg.HasEdge("b", "a"))
+	invNbrs, _ := dg.GetInvNeighbors("d")
+	assert.Equal(t, map[n.Node]float64{"b": 1, "c": 1}, invNbrs)
+}
+
+func TestReadDOTErrors(t *testing.T) {
+	tests := map[string]struct {
+		src          string
+		directed     bool
+		expectedLine int
+		expectedCol  int
+	}{
+		"digraph read as undirected graph": {
+			src:          "digraph {}",
+			expectedLine: 1,
+			expectedCol:  1,
+		},
+		"undirected graph read as digraph": {
+			src:          "\n  graph {}",
+			directed:     true,
+			expectedLine: 2,
+			expectedCol:  3,
+		},
+		"directed edge in undirected graph": {
+			src:          "graph {\n\ta -> b\n}",
+			expectedLine: 2,
+			expectedCol:  4,
+		},
+		"undirected edge in digraph": {
+			src:          "digraph {\n\ta -- b\n}",
+			directed:     true,
+			expectedLine: 2,
+			expectedCol:  4,
+		},
+		"invalid weight": {
+			src:          "graph {\n  a -- b [color=red, weight=heavy]\n}",
+			expectedLine: 2,
+			expectedCol:  29,
+		},
+		"missing closing brace": {
+			src:          "graph {\n  a -- b",
+			expectedLine: 2,
+			expectedCol:  9,
+		},
+		"unterminated string": {
+			src:          "graph {\n  \"a -- b\n}",
+			expectedLine: 2,
+			expectedCol:  3,
+		},
+		"unexpected character": {
+			src:          "graph { a -- b; @ }",
+			expectedLine: 1,
+			expectedCol:  17,
+		},
+		"missing attribute value": {
+			src:          "graph { a [label=] }",
+			expectedLine: 1,
+			expectedCol:  18,
+		},
+		"content after graph": {
+			src:          "graph { } x",
+			expectedLine: 1,
+			expectedCol:  11,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if test.directed {
+				_, err = ReadDirDOT(strings.NewReader(test.src))
+			} else {
+				_, err = ReadDOT(strings.NewReader(test.src))
+			}
+			var syntaxErr *DOTSyntaxError
+			assert.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, test.expectedLine, syntaxErr.Line)
+			assert.Equal(t, test.expectedCol, syntaxErr.Col)
+		})
+	}
+}
+
+func TestReadDOTWgtAttribute(t *testing.T) {
+	for _, src := range []string{
+		"digraph { a -> b [weight=2, wgt=-1.5] }",
+		"digraph { a -> b [wgt=-1.5, weight=2] }",
+		"digraph { edge [wgt=-1.5]; a -> b [weight=2] }",
+	} {
+		dg, err := ReadDirDOT(strings.NewReader(src))
+		assert.Nil(t, err)
+		wgt, _ := dg.GetEdgeWeight("a", "b")
+		assert.Equal(t, -1.5, wgt, src)
+		attrs, _ := dg.GetEdgeAttrs("a", "b")
+		assert.Empty(t, attrs)
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		tg := setupTestGraph()
+		tg.g.AddNode("e f")
+
+		var buf bytes.Buffer
+		assert.Nil(t, tg.g.WriteDOT(&buf, DOTOptions{}))
+		g, err := ReadDOT(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, tg.g.Name, g.Name)
+		assert.Equal(t, tg.g.dirAdj, g.dirAdj)
+	})
+	t.Run("directed", func(t *testing.T) {
+		tdg := setupTestDirGraph()
+
+		var buf bytes.Buffer
+		assert.Nil(t, tdg.dg.WriteDOT(&buf, DOTOptions{}))
+		dg, err := ReadDirDOT(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, tdg.dg.dirAdj, dg.dirAdj)
+		assert.Equal(t, tdg.dg.invAdj, dg.invAdj)
+	})
+}
@@ -0,0 +1,405 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// DuplicatePolicy determines how an edge list reader handles an edge that already exists in the graph
+type DuplicatePolicy int
+
+const (
+	// LastWins replaces the weight of an existing edge with the weight of the duplicate
+	LastWins DuplicatePolicy = iota
+	// FirstWins keeps the weight of an existing edge and ignores the duplicate
+	FirstWins
+	// SumDuplicates adds the weight of the duplicate to the weight of an existing edge
+	SumDuplicates
+	// MaxDuplicate keeps the larger of the weights of an existing edge and the duplicate
+	MaxDuplicate
+	// ErrorOnDuplicate stops reading and returns an error when a duplicate is encountered
+	ErrorOnDuplicate
+)
+
+func (p DuplicatePolicy) validate() error {
+	if p < LastWins || p > ErrorOnDuplicate {
+		return fmt.Errorf("unknown duplicate policy %d", p)
+	}
+	return nil
+}
+
+// Columns maps the fields of an edge list line to the src node, tgt node and weight of an edge
+type Columns struct {
+	Src    int
+	Tgt    int
+	Weight int // a negative index indicates that the edge list has no weight column
+}
+
+// DefaultColumns is the "src tgt [weight]" column mapping of an edge list
+var DefaultColumns = Columns{Src: 0, Tgt: 1, Weight: 2}
+
+func (c Columns) validate() error {
+	if c.Src < 0 || c.Tgt < 0 {
+		return fmt.Errorf("invalid columns %+v: src and tgt columns must not be negative", c)
+	}
+	if c.Src == c.Tgt || c.Src == c.Weight || c.Tgt == c.Weight {
+		return fmt.Errorf("invalid columns %+v: columns must be distinct", c)
+	}
+	return nil
+}
+
+// count returns the number of fields needed to hold all of the mapped columns
+func (c Columns) count() int {
+	return maxInt(maxInt(c.Src, c.Tgt), c.Weight) + 1
+}
+
+// EdgeListReader reads edge lists into graphs using configurable options; the zero value reads
+// whitespace separated "src tgt [weight]" lines and returns an error on any malformed line
+type EdgeListReader struct {
+	// Delimiter separates the fields of a line, which are separated by whitespace if it is empty
+	Delimiter string
+	// Comment is a prefix marking lines to be ignored, no lines are ignored if it is empty
+	Comment string
+	// HeaderLines is the number of lines at the start of each reader to be ignored
+	HeaderLines int
+	// Columns maps the fields of a line to an edge, DefaultColumns is used if it is nil
+	Columns *Columns
+	// Lenient skips malformed lines rather than returning an error and ignores any fields following
+	// the mapped columns of a line that are not attributes
+	Lenient bool
+	// Attributes reads key=value fields following the mapped columns of a line as edge attributes and
+	// key=value fields following the only other field of a line as node attributes, as written by WriteEdgeList
+	Attributes bool
+	// Duplicates is the policy applied to edges that already exist in the graph,
+	// including the reverse of an existing edge of an undirected graph
+	Duplicates DuplicatePolicy
+}
+
+// EdgeListReport summarizes the edges encountered while reading edge lists
+type EdgeListReport struct {
+	Edges      int // number of edges read
+	Duplicates int // number of edges read that already existed in the graph
+	SelfLoops  int // number of edges read from a node to itself
+	Skipped    int // number of malformed lines skipped by a lenient reader
+}
+
+// ParseError is an error encountered while reading a line of an edge list
+type ParseError struct {
+	Line    int    // line number, starting from 1
+	Content string // content of the line
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d %q: %v", e.Line, e.Content, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// NewGraph creates a new undirected graph from edge lists
+func (elr EdgeListReader) NewGraph(name string, readers ...io.ReadCloser) (*Graph, EdgeListReport, error) {
+	g := newGraph(name)
+	report, err := elr.readInto(g, readers)
+	return g, report, err
+}
+
+// NewDirGraph creates a new directed graph from edge lists
+func (elr EdgeListReader) NewDirGraph(name string, readers ...io.ReadCloser) (*DirGraph, EdgeListReport, error) {
+	dg := newDirGraph(name)
+	report, err := elr.readInto(&dg.Graph, readers)
+	return dg, report, err
+}
+
+func (elr EdgeListReader) readInto(g *Graph, readers []io.ReadCloser) (report EdgeListReport, err error) {
+	if err := elr.validate(); err != nil {
+		return report, err
+	}
+	for _, r := range readers {
+		err := g.addFromReader(r, elr, &report)
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (elr EdgeListReader) validate() error {
+	if elr.HeaderLines < 0 {
+		return fmt.Errorf("invalid number of header lines %d", elr.HeaderLines)
+	}
+	if err := elr.columns().validate(); err != nil {
+		return err
+	}
+	return elr.Duplicates.validate()
+}
+
+func (elr EdgeListReader) columns() Columns {
+	if elr.Columns == nil {
+		return DefaultColumns
+	}
+	return *elr.Columns
+}
+
+// nodeAdder adds a node declared by a line of an edge list along with its attributes
+type nodeAdder func(node n.Node, attrs map[string]string) error
+
+// edgeAdder adds an edge declared by a line of an edge list along with its attributes
+type edgeAdder func(src n.Node, tgt n.Node, wgt float64, attrs map[string]string) error
+
+// read reads lines declaring edges by their src, tgt and optional weight columns and lines containing
+// a single field declaring nodes, passing each to the corresponding adder along with any attributes
+func (elr EdgeListReader) read(r io.ReadCloser, report *EdgeListReport, addNode nodeAdder, addEdge edgeAdder) error {
+	defer r.Close()
+	lineNum := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lineNum <= elr.HeaderLines {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || (elr.Comment != "" && strings.HasPrefix(trimmed, elr.Comment)) {
+			continue
+		}
+
+		err := elr.readLine(trimmed, addNode, addEdge)
+		if err == nil {
+			continue
+		}
+		if _, malformed := err.(malformedLineError); malformed && elr.Lenient {
+			report.Skipped++
+			continue
+		}
+		return &ParseError{Line: lineNum, Content: line, Err: err}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return &ParseError{Line: lineNum + 1, Err: err}
+	}
+	return nil
+}
+
+// malformedLineError is an error in the content of a line, which is skipped by a lenient reader
+type malformedLineError struct {
+	msg string
+}
+
+func (e malformedLineError) Error() string {
+	return e.msg
+}
+
+func malformedf(format string, args ...interface{}) error {
+	return malformedLineError{msg: fmt.Sprintf(format, args...)}
+}
+
+func (elr EdgeListReader) readLine(line string, addNode nodeAdder, addEdge edgeAdder) error {
+	fields := elr.split(line)
+	for _, field := range fields {
+		if field == "" {
+			return malformedf("empty field")
+		}
+	}
+
+	if len(fields) == 1 || (elr.Attributes && allAttrs(fields[1:])) {
+		// a line containing a single field declares a node that need not have edges
+		attrs, err := parseAttrs(fields[1:])
+		if err != nil {
+			return err
+		}
+		return addNode(n.Node(fields[0]), attrs)
+	}
+
+	cols := elr.columns()
+	if len(fields) <= cols.Src || len(fields) <= cols.Tgt {
+		return malformedf("expected at least %d fields, found %d", maxInt(cols.Src, cols.Tgt)+1, len(fields))
+	}
+	weight := defaultWgt
+	if cols.Weight >= 0 && len(fields) > cols.Weight {
+		var err error
+		weight, err = strconv.ParseFloat(fields[cols.Weight], 64)
+		if err != nil {
+			return malformedf("invalid weight %q", fields[cols.Weight])
+		}
+	}
+	attrs := map[string]string{}
+	if len(fields) > cols.count() {
+		extra := fields[cols.count():]
+		if !elr.Lenient {
+			for _, field := range extra {
+				if !elr.Attributes || !strings.Contains(field, "=") {
+					return malformedf("unexpected field %q", field)
+				}
+			}
+		}
+		if elr.Attributes {
+			var err error
+			attrs, err = parseAttrs(extra)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return addEdge(n.Node(fields[cols.Src]), n.Node(fields[cols.Tgt]), weight, attrs)
+}
+
+func (elr EdgeListReader) split(line string) []string {
+	if elr.Delimiter == "" {
+		return strings.Fields(line)
+	}
+	fields := strings.Split(line, elr.Delimiter)
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+	}
+	return fields
+}
+
+func allAttrs(fields []string) bool {
+	for _, field := range fields {
+		if !strings.Contains(field, "=") {
+			return false
+		}
+	}
+	return true
+}
+
+// parseAttrs parses the key=value attribute fields of a line, ignoring any other fields
+func parseAttrs(fields []string) (map[string]string, error) {
+	attrs := map[string]string{}
+	for _, field := range fields {
+		key, val, isAttr := strings.Cut(field, "=")
+		if !isAttr {
+			continue
+		}
+		if key == "" {
+			return nil, malformedf("attribute %q has an empty key", field)
+		}
+		attrs[key] = val
+	}
+	return attrs, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// WriteEdgeList writes a Graph in the "src tgt weight [key=value ...]" format read by NewGraph, or by an
+// EdgeListReader with Attributes set if the graph has attributes, writing each undirected edge once and
+// each node without edges or with attributes as a "node [key=value ...]" line
+func (g *Graph) WriteEdgeList(w io.Writer) error {
+	return g.writeEdgeList(w, g.GetNodes(), false)
+}
+
+// WriteEdgeList writes a DirGraph in the "src tgt weight [key=value ...]" format read by NewDirGraph, or
+// by an EdgeListReader with Attributes set if the graph has attributes, writing each node without edges
+// or with attributes as a "node [key=value ...]" line
+func (dg *DirGraph) WriteEdgeList(w io.Writer) error {
+	return dg.writeEdgeList(w, dg.GetNodes(), true)
+}
+
+func (g *Graph) writeEdgeList(w io.Writer, nodes []n.Node, directed bool) error {
+	sortNodes(nodes)
+	bw := bufio.NewWriter(w)
+
+	// nodes are declared before any edges so that all of the lines are sorted within each group
+	for _, node := range nodes {
+		if err := checkField(string(node)); err != nil {
+			return err
+		}
+		nbrs, _ := g.GetNeighbors(node)
+		invNbrs, _ := g.GetInvNeighbors(node)
+		attrs := g.nodeAttrs[node]
+		if len(nbrs) > 0 || len(invNbrs) > 0 {
+			if len(attrs) == 0 {
+				continue
+			}
+		}
+		attrFields, err := formatAttrs(attrs)
+		if err != nil {
+			return fmt.Errorf("node %s: %v", node, err)
+		}
+		fmt.Fprintln(bw, strings.Join(append([]string{string(node)}, attrFields...), " "))
+	}
+
+	for _, e := range g.sortedEdges(nodes, directed) {
+		attrs, _ := g.edgeAttrs.get(e.src, e.tgt)
+		attrFields, err := formatAttrs(attrs)
+		if err != nil {
+			return fmt.Errorf("edge %s %s: %v", e.src, e.tgt, err)
+		}
+		fields := []string{string(e.src), string(e.tgt), strconv.FormatFloat(e.wgt, 'g', -1, 64)}
+		fmt.Fprintln(bw, strings.Join(append(fields, attrFields...), " "))
+	}
+	return bw.Flush()
+}
+
+type edge struct {
+	src n.Node
+	tgt n.Node
+	wgt float64
+}
+
+// sortedEdges gets the edges from sorted nodes sorted by tgt node, including
+// each undirected edge only once from its smaller node
+func (g *Graph) sortedEdges(nodes []n.Node, directed bool) (edges []edge) {
+	for _, src := range nodes {
+		nbrs, _ := g.GetNeighbors(src)
+		tgts := make([]n.Node, 0, len(nbrs))
+		for tgt := range nbrs {
+			if directed || src <= tgt {
+				tgts = append(tgts, tgt)
+			}
+		}
+		sortNodes(tgts)
+		for _, tgt := range tgts {
+			edges = append(edges, edge{src: src, tgt: tgt, wgt: nbrs[tgt]})
+		}
+	}
+	return edges
+}
+
+// formatAttrs formats attributes as key=value fields sorted by key
+func formatAttrs(attrs Attributes) ([]string, error) {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, 0, len(attrs))
+	for _, key := range keys {
+		val := formatAttrValue(attrs[key])
+		if key == "" || strings.Contains(key, "=") {
+			return nil, fmt.Errorf("attribute key %q cannot be written", key)
+		}
+		if err := checkField(key + "=" + val); err != nil {
+			return nil, err
+		}
+		fields = append(fields, key+"="+val)
+	}
+	return fields, nil
+}
+
+// checkField returns an error if a field cannot be written to an edge list and read back
+func checkField(field string) error {
+	if field == "" || strings.IndexFunc(field, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%q cannot be written to an edge list field", field)
+	}
+	return nil
+}
+
+func sortNodes(nodes []n.Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+}
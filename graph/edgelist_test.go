@@ -0,0 +1,369 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func TestEdgeListReaderDuplicates(t *testing.T) {
+	f := "a b 1\nc c 2\nb a 3\na b 2\nc c 1"
+
+	tests := map[string]struct {
+		policy        DuplicatePolicy
+		expectedAB    float64
+		expectedCC    float64
+		expectedDupes int
+		shouldErr     bool
+	}{
+		"last wins": {
+			policy:        LastWins,
+			expectedAB:    2,
+			expectedCC:    1,
+			expectedDupes: 3,
+		},
+		"first wins": {
+			policy:        FirstWins,
+			expectedAB:    1,
+			expectedCC:    2,
+			expectedDupes: 3,
+		},
+		"sum": {
+			policy:        SumDuplicates,
+			expectedAB:    6,
+			expectedCC:    3,
+			expectedDupes: 3,
+		},
+		"max": {
+			policy:        MaxDuplicate,
+			expectedAB:    3,
+			expectedCC:    2,
+			expectedDupes: 3,
+		},
+		"error": {
+			policy:        ErrorOnDuplicate,
+			expectedDupes: 1,
+			shouldErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			elr := EdgeListReader{Duplicates: test.policy}
+			reader := ioutil.NopCloser(bytes.NewReader([]byte(f)))
+			g, report, err := elr.NewGraph("test", reader)
+			assert.Equal(t, test.expectedDupes, report.Duplicates)
+			if test.shouldErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, EdgeListReport{Edges: 5, Duplicates: 3, SelfLoops: 2}, report)
+			wgt, _ := g.GetEdgeWeight("b", "a")
+			assert.Equal(t, test.expectedAB, wgt)
+			wgt, _ = g.GetEdgeWeight("c", "c")
+			assert.Equal(t, test.expectedCC, wgt)
+		})
+	}
+}
+
+func TestEdgeListReaderDuplicatesDirected(t *testing.T) {
+	t.Run("reversed edges of a directed graph are not duplicates", func(t *testing.T) {
+		elr := EdgeListReader{Duplicates: SumDuplicates}
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b 1\nb a 3\na b 2")))
+		dg, report, err := elr.NewDirGraph("test", reader)
+		assert.Nil(t, err)
+		assert.Equal(t, EdgeListReport{Edges: 3, Duplicates: 1}, report)
+		wgt, _ := dg.GetEdgeWeight("a", "b")
+		assert.Equal(t, 3.0, wgt)
+		wgt, _ = dg.GetEdgeWeight("b", "a")
+		assert.Equal(t, 3.0, wgt)
+		invNbrs, _ := dg.GetInvNeighbors("b")
+		assert.Equal(t, 3.0, invNbrs["a"])
+	})
+	t.Run("duplicates are counted across readers", func(t *testing.T) {
+		elr := EdgeListReader{Duplicates: SumDuplicates}
+		r1 := ioutil.NopCloser(bytes.NewReader([]byte("a b")))
+		r2 := ioutil.NopCloser(bytes.NewReader([]byte("a b")))
+		dg, report, err := elr.NewDirGraph("test", r1, r2)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.Duplicates)
+		wgt, _ := dg.GetEdgeWeight("a", "b")
+		assert.Equal(t, 2.0, wgt)
+	})
+}
+
+func TestEdgeListReaderOptions(t *testing.T) {
+	tests := map[string]struct {
+		elr      EdgeListReader
+		f        string
+		expected []testEdge
+		nodes    []n.Node
+		skipped  int
+	}{
+		"whitespace separated with tabs and repeated spaces": {
+			elr:      EdgeListReader{},
+			f:        "a\tb 1.5\nb   c\t\t2\r\n  c d  \n",
+			expected: []testEdge{{"a", "b", 1.5}, {"b", "c", 2}, {"c", "d", 1}},
+			nodes:    []n.Node{"a", "b", "c", "d"},
+		},
+		"csv with header and comments": {
+			elr:      EdgeListReader{Delimiter: ",", Comment: "#", HeaderLines: 1},
+			f:        "source,target,weight\n# a comment\na, b ,1.5\n  # indented comment\nb,c,2\nd",
+			expected: []testEdge{{"a", "b", 1.5}, {"b", "c", 2}},
+			nodes:    []n.Node{"a", "b", "c", "d"},
+		},
+		"column mapping": {
+			elr:      EdgeListReader{Columns: &Columns{Src: 2, Tgt: 0, Weight: 1}},
+			f:        "b 1.5 a\nc 2 b",
+			expected: []testEdge{{"a", "b", 1.5}, {"b", "c", 2}},
+			nodes:    []n.Node{"a", "b", "c"},
+		},
+		"column mapping without weight column": {
+			elr:      EdgeListReader{Columns: &Columns{Src: 1, Tgt: 2, Weight: -1}, Lenient: true},
+			f:        "1 a b 3.5\n2 b c 7",
+			expected: []testEdge{{"a", "b", 1}, {"b", "c", 1}},
+			nodes:    []n.Node{"a", "b", "c"},
+		},
+		"lenient skips malformed lines": {
+			elr:      EdgeListReader{Delimiter: ",", Lenient: true, Attributes: true},
+			f:        "a,b,x\na,,1\nb,c,2\nc,=x\nc,d",
+			expected: []testEdge{{"b", "c", 2}, {"c", "d", 1}},
+			nodes:    []n.Node{"b", "c", "d"},
+			skipped:  3,
+		},
+		"fields with equals are nodes without attributes": {
+			elr:      EdgeListReader{},
+			f:        "a b=c 1\nb=c k=v",
+			expected: []testEdge{{"a", "b=c", 1}, {"b=c", "k=v", 1}},
+			nodes:    []n.Node{"a", "b=c", "k=v"},
+		},
+		"attributes follow the mapped columns": {
+			elr:      EdgeListReader{Attributes: true},
+			f:        "a b=c 1 k=v\nb=c d 2\ne k=v",
+			expected: []testEdge{{"a", "b=c", 1}, {"b=c", "d", 2}},
+			nodes:    []n.Node{"a", "b=c", "d", "e"},
+		},
+		"lenient skips lines with too few fields": {
+			elr:      EdgeListReader{Columns: &Columns{Src: 0, Tgt: 2, Weight: 1}, Lenient: true},
+			f:        "a 1\na 2 b",
+			expected: []testEdge{{"a", "b", 2}},
+			nodes:    []n.Node{"a", "b"},
+			skipped:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader := ioutil.NopCloser(bytes.NewReader([]byte(test.f)))
+			dg, report, err := test.elr.NewDirGraph("test", reader)
+			assert.Nil(t, err)
+			assert.Equal(t, test.skipped, report.Skipped)
+			assert.Equal(t, len(test.expected), report.Edges)
+			assert.ElementsMatch(t, test.nodes, dg.GetNodes())
+			for _, e := range test.expected {
+				wgt, ok := dg.GetEdgeWeight(e.src, e.tgt)
+				assert.True(t, ok)
+				assert.Equal(t, e.wgt, wgt)
+			}
+		})
+	}
+}
+
+func TestEdgeListReaderAttributes(t *testing.T) {
+	f := "a b=c 1 k=v\nd label=x count=2\n"
+	elr := EdgeListReader{Attributes: true}
+	dg, _, err := elr.NewDirGraph("test", ioutil.NopCloser(bytes.NewReader([]byte(f))))
+	assert.Nil(t, err)
+
+	attrs, ok := dg.GetEdgeAttrs("a", "b=c")
+	assert.True(t, ok)
+	val, _ := attrs.GetString("k")
+	assert.Equal(t, "v", val)
+	attrs, ok = dg.GetNodeAttrs("d")
+	assert.True(t, ok)
+	val, _ = attrs.GetString("label")
+	assert.Equal(t, "x", val)
+	count, _ := attrs.GetInt("count")
+	assert.Equal(t, 2, count)
+}
+
+func TestEdgeListReaderParseError(t *testing.T) {
+	tests := map[string]struct {
+		elr          EdgeListReader
+		f            string
+		expectedLine int
+		expectedText string
+	}{
+		"invalid weight": {
+			elr:          EdgeListReader{},
+			f:            "a b\n\nb c x",
+			expectedLine: 3,
+			expectedText: "b c x",
+		},
+		"empty field": {
+			elr:          EdgeListReader{Delimiter: ","},
+			f:            "a,b\n,c",
+			expectedLine: 2,
+			expectedText: ",c",
+		},
+		"too few fields": {
+			elr:          EdgeListReader{Comment: "#", Columns: &Columns{Src: 0, Tgt: 2, Weight: -1}},
+			f:            "# header\na b",
+			expectedLine: 2,
+			expectedText: "a b",
+		},
+		"unexpected field": {
+			elr:          EdgeListReader{},
+			f:            "a b 1\nb c 2 x",
+			expectedLine: 2,
+			expectedText: "b c 2 x",
+		},
+		"unexpected field following attributes": {
+			elr:          EdgeListReader{Attributes: true},
+			f:            "a b 1 k=v\nb c 2 k=v x",
+			expectedLine: 2,
+			expectedText: "b c 2 k=v x",
+		},
+		"duplicate edge": {
+			elr:          EdgeListReader{HeaderLines: 1, Duplicates: ErrorOnDuplicate},
+			f:            "src tgt\na b\nb a",
+			expectedLine: 3,
+			expectedText: "b a",
+		},
+		"duplicate edge is an error even when lenient": {
+			elr:          EdgeListReader{Lenient: true, Duplicates: ErrorOnDuplicate},
+			f:            "a b\na b",
+			expectedLine: 2,
+			expectedText: "a b",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader := ioutil.NopCloser(bytes.NewReader([]byte(test.f)))
+			_, _, err := test.elr.NewGraph("test", reader)
+			var parseErr *ParseError
+			assert.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, test.expectedLine, parseErr.Line)
+			assert.Equal(t, test.expectedText, parseErr.Content)
+			assert.Contains(t, err.Error(), fmt.Sprintf("line %d", test.expectedLine))
+		})
+	}
+}
+
+func TestEdgeListReaderInvalidOptions(t *testing.T) {
+	tests := map[string]EdgeListReader{
+		"negative header lines":    {HeaderLines: -1},
+		"negative src column":      {Columns: &Columns{Src: -1, Tgt: 1, Weight: 2}},
+		"repeated columns":         {Columns: &Columns{Src: 0, Tgt: 1, Weight: 1}},
+		"unknown duplicate policy": {Duplicates: DuplicatePolicy(-1)},
+	}
+
+	for name, elr := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := elr.NewDirGraph("test")
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestWriteEdgeList(t *testing.T) {
+	f := "c d 1.1\nb a\nd d 3\na c 2.5\ne\nc b 1e-07"
+
+	t.Run("undirected graph writes each edge once", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte(f)))
+		g, _ := NewGraph("test", reader)
+		var buf bytes.Buffer
+		err := g.WriteEdgeList(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, "e\na b 1\na c 2.5\nb c 1e-07\nc d 1.1\nd d 3\n", buf.String())
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte(f)))
+		dg, _ := NewDirGraph("test", reader)
+		var buf bytes.Buffer
+		err := dg.WriteEdgeList(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, "e\na c 2.5\nb a 1\nc b 1e-07\nc d 1.1\nd d 3\n", buf.String())
+	})
+	t.Run("empty graph", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		var buf bytes.Buffer
+		err := g.WriteEdgeList(&buf)
+		assert.Nil(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestWriteEdgeListAttributes(t *testing.T) {
+	dg, _ := NewDirGraph("test")
+	dg.AddEdge("a", "b", 2)
+	dg.AddEdge("b", "c")
+	dg.AddEdge("c", "x=y")
+	dg.AddNode("d")
+	dg.SetNodeAttr("a", "label", "first")
+	dg.SetNodeAttr("d", "count", 3)
+	dg.SetEdgeAttr("a", "b", "since", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	dg.SetEdgeAttr("a", "b", "active", true)
+
+	var buf bytes.Buffer
+	err := dg.WriteEdgeList(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "a label=first\nd count=3\na b 2 active=true since=2020-01-02T03:04:05Z\nb c 1\nc x=y 1\n", buf.String())
+
+	// round trip
+	rt, _, err := EdgeListReader{Attributes: true}.NewDirGraph("test", ioutil.NopCloser(&buf))
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, dg.GetNodes(), rt.GetNodes())
+	attrs, _ := rt.GetNodeAttrs("d")
+	count, _ := attrs.GetInt("count")
+	assert.Equal(t, 3, count)
+	attrs, _ = rt.GetEdgeAttrs("a", "b")
+	since, _ := attrs.GetTime("since")
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), since)
+	wgt, _ := rt.GetEdgeWeight("a", "b")
+	assert.Equal(t, 2.0, wgt)
+	assert.True(t, rt.HasEdge("c", "x=y"))
+}
+
+func TestWriteEdgeListRoundTrip(t *testing.T) {
+	tg := setupTestGraph()
+	tg.g.AddNode("isolated")
+	tg.g.RemoveEdge("d", "d")
+
+	var buf bytes.Buffer
+	err := tg.g.WriteEdgeList(&buf)
+	assert.Nil(t, err)
+	g, err := NewGraph("test", ioutil.NopCloser(&buf))
+	assert.Nil(t, err)
+	assert.Equal(t, tg.g.dirAdj, g.dirAdj)
+}
+
+func TestWriteEdgeListInvalidFields(t *testing.T) {
+	tests := map[string]func(g *Graph){
+		"node with whitespace": func(g *Graph) { g.AddEdge("a b", "c") },
+		"attribute with space": func(g *Graph) { g.SetNodeAttr("a", "label", "two words") },
+		"attribute key with equals": func(g *Graph) {
+			g.AddEdge("a", "b")
+			g.SetEdgeAttr("a", "b", "k=v", 1)
+		},
+	}
+
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, _ := NewGraph("test")
+			setup(g)
+			var buf bytes.Buffer
+			err := g.WriteEdgeList(&buf)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+const (
+	graphMLNamespace  = "http://graphml.graphdrawing.org/xmlns"
+	graphMLWeightKey  = "weight"
+	graphMLDirected   = "directed"
+	graphMLUndirected = "undirected"
+)
+
+type graphMLDoc struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graphs  []graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID      string  `xml:"id,attr"`
+	For     string  `xml:"for,attr,omitempty"`
+	Name    string  `xml:"attr.name,attr,omitempty"`
+	Type    string  `xml:"attr.type,attr,omitempty"`
+	Default *string `xml:"default,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+	HyperEdges  []struct{}    `xml:"hyperedge"`
+}
+
+type graphMLNode struct {
+	ID     string        `xml:"id,attr"`
+	Data   []graphMLData `xml:"data"`
+	Graphs []struct{}    `xml:"graph"`
+}
+
+type graphMLEdge struct {
+	ID       string        `xml:"id,attr,omitempty"`
+	Source   string        `xml:"source,attr"`
+	Target   string        `xml:"target,attr"`
+	Directed string        `xml:"directed,attr,omitempty"`
+	Data     []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ReadGraphML reads an undirected GraphML document into a Graph named by the id of its graph element,
+// mapping the edge data with the attribute name "weight" to edge weights and all other node and edge
+// data to attributes; an error is returned if the document contains directed edges, including the edges
+// of a document whose edgedefault is directed or unspecified, so ReadAnyGraphML should be used to read a
+// document whose direction is not known in advance
+func ReadGraphML(r io.Reader) (*Graph, error) {
+	doc, err := decodeGraphML(r)
+	if err != nil {
+		return nil, err
+	}
+	g := newGraph(doc.Graphs[0].ID)
+	err = doc.addTo(g, false)
+	return g, err
+}
+
+// ReadDirGraphML reads a directed GraphML document into a DirGraph named by the id of its graph element,
+// mapping the edge data with the attribute name "weight" to edge weights and all other node and edge
+// data to attributes; an error is returned if the document contains undirected edges, including the edges
+// of a document whose edgedefault is undirected, so ReadAnyGraphML should be used to read a document whose
+// direction is not known in advance
+func ReadDirGraphML(r io.Reader) (*DirGraph, error) {
+	doc, err := decodeGraphML(r)
+	if err != nil {
+		return nil, err
+	}
+	dg := newDirGraph(doc.Graphs[0].ID)
+	err = doc.addTo(&dg.Graph, true)
+	return dg, err
+}
+
+// ReadAnyGraphML reads a GraphML document into a *DirGraph if the edgedefault of its graph element is
+// directed or unspecified and into a *Graph if it is undirected, as described by ReadDirGraphML and
+// ReadGraphML; an error is returned if the document contains edges of the other direction
+func ReadAnyGraphML(r io.Reader) (ReadOnlyGraph, error) {
+	doc, err := decodeGraphML(r)
+	if err != nil {
+		return nil, err
+	}
+	gml := doc.Graphs[0]
+	if edgeIsDirected("", gml.EdgeDefault) {
+		dg := newDirGraph(gml.ID)
+		err = doc.addTo(&dg.Graph, true)
+		return dg, err
+	}
+	g := newGraph(gml.ID)
+	err = doc.addTo(g, false)
+	return g, err
+}
+
+func decodeGraphML(r io.Reader) (*graphMLDoc, error) {
+	doc := &graphMLDoc{}
+	if err := xml.NewDecoder(r).Decode(doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Graphs) != 1 {
+		return nil, fmt.Errorf("expected one graph in GraphML document, found %d", len(doc.Graphs))
+	}
+	gml := doc.Graphs[0]
+	if len(gml.HyperEdges) > 0 {
+		return nil, fmt.Errorf("GraphML hyperedges are not supported")
+	}
+	for _, node := range gml.Nodes {
+		if len(node.Graphs) > 0 {
+			return nil, fmt.Errorf("GraphML nested graph in node %s is not supported", node.ID)
+		}
+	}
+	return doc, nil
+}
+
+func (doc *graphMLDoc) addTo(g *Graph, directed bool) error {
+	gml := doc.Graphs[0]
+	keys := map[string]graphMLKey{}
+	for _, key := range doc.Keys {
+		keys[key.ID] = key
+	}
+
+	for _, node := range gml.Nodes {
+		attrs, err := doc.attrs("node", node.Data, keys)
+		if err != nil {
+			return fmt.Errorf("GraphML node %s: %v", node.ID, err)
+		}
+		g.AddNode(n.Node(node.ID))
+		for key, val := range attrs {
+			g.SetNodeAttr(n.Node(node.ID), key, val)
+		}
+	}
+
+	for _, e := range gml.Edges {
+		if edgeIsDirected(e.Directed, gml.EdgeDefault) != directed {
+			return fmt.Errorf("GraphML edge %s -> %s has the wrong directedness for the graph", e.Source, e.Target)
+		}
+		attrs, err := doc.attrs("edge", e.Data, keys)
+		if err != nil {
+			return fmt.Errorf("GraphML edge %s -> %s: %v", e.Source, e.Target, err)
+		}
+		wgt := defaultWgt
+		if val, ok := attrs[graphMLWeightKey]; ok {
+			f, isFloat := attrs.GetFloat(graphMLWeightKey)
+			if !isFloat {
+				return fmt.Errorf("GraphML edge %s -> %s: invalid weight %v", e.Source, e.Target, val)
+			}
+			wgt = f
+			delete(attrs, graphMLWeightKey)
+		}
+		src, tgt := n.Node(e.Source), n.Node(e.Target)
+		g.AddEdge(src, tgt, wgt)
+		for key, val := range attrs {
+			g.SetEdgeAttr(src, tgt, key, val)
+		}
+	}
+	return nil
+}
+
+// attrs gets the attributes of an element from its data and the defaults of the keys declared for its
+// element type, using the id of any undeclared key as the name of an attribute with a string value
+func (doc *graphMLDoc) attrs(elem string, data []graphMLData, keys map[string]graphMLKey) (Attributes, error) {
+	attrs := Attributes{}
+	for _, key := range doc.Keys {
+		if key.Default == nil || !key.appliesTo(elem) {
+			continue
+		}
+		val, err := key.parse(*key.Default)
+		if err != nil {
+			return nil, err
+		}
+		attrs[key.name()] = val
+	}
+	for _, d := range data {
+		key, ok := keys[d.Key]
+		if !ok {
+			attrs[d.Key] = strings.TrimSpace(d.Value)
+			continue
+		}
+		val, err := key.parse(d.Value)
+		if err != nil {
+			return nil, err
+		}
+		attrs[key.name()] = val
+	}
+	return attrs, nil
+}
+
+// appliesTo returns true if a key is declared for an element, where a key without a for attribute
+// applies to all elements
+func (key graphMLKey) appliesTo(elem string) bool {
+	return key.For == elem || key.For == "all" || key.For == ""
+}
+
+func (key graphMLKey) name() string {
+	if key.Name == "" {
+		return key.ID
+	}
+	return key.Name
+}
+
+// parse converts a data value to the Go type corresponding to the attr.type of a key
+func (key graphMLKey) parse(val string) (interface{}, error) {
+	val = strings.TrimSpace(val)
+	switch key.Type {
+	case "boolean":
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value %q for key %s", val, key.ID)
+		}
+		return b, nil
+	case "int", "long":
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value %q for key %s", val, key.ID)
+		}
+		return i, nil
+	case "float", "double":
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid floating point value %q for key %s", val, key.ID)
+		}
+		return f, nil
+	}
+	return val, nil
+}
+
+// edgeIsDirected evaluates whether an edge is directed from its directed attribute and the edgedefault of its
+// graph, which is directed when not specified
+func edgeIsDirected(edgeDirected string, edgeDefault string) bool {
+	if edgeDirected != "" {
+		return edgeDirected == "true"
+	}
+	return edgeDefault != graphMLUndirected
+}
+
+// WriteGraphML writes a Graph as an undirected GraphML document with each undirected edge written once,
+// edge weights written as "weight" data and node and edge attributes written as data of inferred types
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	return g.writeGraphML(w, g.GetNodes(), false)
+}
+
+// WriteGraphML writes a DirGraph as a directed GraphML document with edge weights written as "weight" data
+// and node and edge attributes written as data of inferred types
+func (dg *DirGraph) WriteGraphML(w io.Writer) error {
+	return dg.writeGraphML(w, dg.GetNodes(), true)
+}
+
+func (g *Graph) writeGraphML(w io.Writer, nodes []n.Node, directed bool) error {
+	sortNodes(nodes)
+	edges := g.sortedEdges(nodes, directed)
+
+	nodeAttrs := []Attributes{}
+	for _, node := range nodes {
+		nodeAttrs = append(nodeAttrs, g.nodeAttrs[node])
+	}
+	edgeAttrs := []Attributes{}
+	for _, e := range edges {
+		attrs, _ := g.edgeAttrs.get(e.src, e.tgt)
+		if _, ok := attrs[graphMLWeightKey]; ok {
+			return fmt.Errorf("edge %s %s has an attribute that conflicts with its weight", e.src, e.tgt)
+		}
+		edgeAttrs = append(edgeAttrs, attrs)
+	}
+
+	weightKey := graphMLKey{ID: graphMLWeightKey, For: "edge", Name: graphMLWeightKey, Type: "double"}
+	// data keys are numbered separately from the "e" prefixed edge ids
+	nodeKeys := inferGraphMLKeys("node", 0, nodeAttrs)
+	edgeKeys := inferGraphMLKeys("edge", len(nodeKeys), edgeAttrs)
+
+	edgeDefault := graphMLUndirected
+	if directed {
+		edgeDefault = graphMLDirected
+	}
+	gml := graphMLGraph{ID: g.Name, EdgeDefault: edgeDefault}
+	for i, node := range nodes {
+		gml.Nodes = append(gml.Nodes, graphMLNode{
+			ID:   string(node),
+			Data: graphMLDataOf(nodeAttrs[i], nodeKeys),
+		})
+	}
+	for i, e := range edges {
+		data := []graphMLData{{Key: weightKey.ID, Value: strconv.FormatFloat(e.wgt, 'g', -1, 64)}}
+		gml.Edges = append(gml.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: string(e.src),
+			Target: string(e.tgt),
+			Data:   append(data, graphMLDataOf(edgeAttrs[i], edgeKeys)...),
+		})
+	}
+
+	doc := graphMLDoc{
+		Xmlns:  graphMLNamespace,
+		Keys:   append(append([]graphMLKey{weightKey}, keysOf(nodeKeys)...), keysOf(edgeKeys)...),
+		Graphs: []graphMLGraph{gml},
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// inferGraphMLKeys declares a key for each attribute name, with the attr.type inferred from the Go types
+// of its values and falling back to string when its values are of different types, keyed by attribute name
+// and with "d" prefixed ids numbered from firstID
+func inferGraphMLKeys(elem string, firstID int, attrsList []Attributes) map[string]graphMLKey {
+	types := map[string]string{}
+	for _, attrs := range attrsList {
+		for name, val := range attrs {
+			typ := graphMLType(val)
+			if prev, ok := types[name]; ok && prev != typ {
+				typ = "string"
+			}
+			types[name] = typ
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := map[string]graphMLKey{}
+	for i, name := range names {
+		keys[name] = graphMLKey{ID: "d" + strconv.Itoa(firstID+i), For: elem, Name: name, Type: types[name]}
+	}
+	return keys
+}
+
+func graphMLType(val interface{}) string {
+	switch val.(type) {
+	case bool:
+		return "boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+		return "long"
+	case float32, float64:
+		return "double"
+	}
+	return "string"
+}
+
+// keysOf gets keys sorted by id
+func keysOf(keys map[string]graphMLKey) []graphMLKey {
+	sorted := make([]graphMLKey, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i].ID) < len(sorted[j].ID) ||
+			(len(sorted[i].ID) == len(sorted[j].ID) && sorted[i].ID < sorted[j].ID)
+	})
+	return sorted
+}
+
+// graphMLDataOf gets the data of attributes sorted by key id
+func graphMLDataOf(attrs Attributes, keys map[string]graphMLKey) []graphMLData {
+	data := []graphMLData{}
+	for _, key := range keysOf(keys) {
+		if val, ok := attrs[key.Name]; ok {
+			data = append(data, graphMLData{Key: key.ID, Value: formatAttrValue(val)})
+		}
+	}
+	return data
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+const testGraphML = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="color" attr.type="string">
+    <default>yellow</default>
+  </key>
+  <key id="d1" for="edge" attr.name="weight" attr.type="double">
+    <default>1.5</default>
+  </key>
+  <key id="d2" for="edge" attr.name="active" attr.type="boolean"/>
+  <key id="d3" for="node" attr.name="size" attr.type="int"/>
+  <graph id="G" edgedefault="%s">
+    <node id="a">
+      <data key="d0">green</data>
+      <data key="d3">4</data>
+    </node>
+    <node id="b"/>
+    <node id="c"/>
+    <node id="d">
+      <data key="unknown"> kept </data>
+    </node>
+    <edge id="e0" source="a" target="b">
+      <data key="d1">2</data>
+      <data key="d2">true</data>
+    </edge>
+    <edge id="e1" source="b" target="c"/>
+  </graph>
+</graphml>`
+
+func graphMLWithEdgeDefault(edgeDefault string) *strings.Reader {
+	return strings.NewReader(strings.Replace(testGraphML, "%s", edgeDefault, 1))
+}
+
+func TestReadGraphML(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		g, err := ReadGraphML(graphMLWithEdgeDefault("undirected"))
+		assert.Nil(t, err)
+		assert.Equal(t, "G", g.Name)
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d"}, g.GetNodes())
+
+		wgt, _ := g.GetEdgeWeight("b", "a")
+		assert.Equal(t, 2.0, wgt)
+		wgt, _ = g.GetEdgeWeight("c", "b")
+		assert.Equal(t, 1.5, wgt)
+
+		attrs, _ := g.GetEdgeAttrs("a", "b")
+		assert.Equal(t, Attributes{"active": true}, attrs)
+		attrs, _ = g.GetEdgeAttrs("b", "c")
+		assert.Empty(t, attrs)
+
+		attrs, _ = g.GetNodeAttrs("a")
+		assert.Equal(t, Attributes{"color": "green", "size": 4}, attrs)
+		attrs, _ = g.GetNodeAttrs("b")
+		assert.Equal(t, Attributes{"color": "yellow"}, attrs)
+		attrs, _ = g.GetNodeAttrs("d")
+		assert.Equal(t, Attributes{"color": "yellow", "unknown": "kept"}, attrs)
+	})
+	t.Run("directed", func(t *testing.T) {
+		dg, err := ReadDirGraphML(graphMLWithEdgeDefault("directed"))
+		assert.Nil(t, err)
+		assert.True(t, dg.HasEdge("a", "b"))
+		assert.False(t, dg.HasEdge("b", "a"))
+		invNbrs, _ := dg.GetInvNeighbors("c")
+		assert.Equal(t, map[n.Node]float64{"b": 1.5}, invNbrs)
+	})
+	t.Run("key without for applies to all elements", func(t *testing.T) {
+		doc := `<graphml>
+  <key id="c" attr.name="color" attr.type="string"><default>red</default></key>
+  <graph edgedefault="undirected">
+    <node id="a"/>
+    <node id="b"><data key="c">blue</data></node>
+    <edge source="a" target="b"/>
+  </graph>
+</graphml>`
+		g, err := ReadGraphML(strings.NewReader(doc))
+		assert.Nil(t, err)
+		attrs, _ := g.GetNodeAttrs("a")
+		assert.Equal(t, Attributes{"color": "red"}, attrs)
+		attrs, _ = g.GetNodeAttrs("b")
+		assert.Equal(t, Attributes{"color": "blue"}, attrs)
+		attrs, _ = g.GetEdgeAttrs("a", "b")
+		assert.Equal(t, Attributes{"color": "red"}, attrs)
+	})
+}
+
+func TestReadAnyGraphML(t *testing.T) {
+	tests := map[string]struct {
+		edgeDefault string
+		directed    bool
+	}{
+		"undirected":  {edgeDefault: "undirected"},
+		"directed":    {edgeDefault: "directed", directed: true},
+		"unspecified": {edgeDefault: "", directed: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, err := ReadAnyGraphML(graphMLWithEdgeDefault(test.edgeDefault))
+			assert.Nil(t, err)
+			if test.directed {
+				assert.IsType(t, &DirGraph{}, g)
+			} else {
+				assert.IsType(t, &Graph{}, g)
+			}
+			assert.Equal(t, test.directed, g.Directed())
+			assert.True(t, g.HasEdge("a", "b"))
+			assert.Equal(t, !test.directed, g.HasEdge("b", "a"))
+		})
+	}
+
+	t.Run("edge of the other direction should error", func(t *testing.T) {
+		doc := `<graphml><graph edgedefault="undirected"><edge source="a" target="b" directed="true"/></graph></graphml>`
+		_, err := ReadAnyGraphML(strings.NewReader(doc))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestReadGraphMLErrors(t *testing.T) {
+	tests := map[string]struct {
+		doc      string
+		directed bool
+	}{
+		"directed document read as undirected graph": {
+			doc: strings.Replace(testGraphML, "%s", "directed", 1),
+		},
+		"undirected document read as directed graph": {
+			doc:      strings.Replace(testGraphML, "%s", "undirected", 1),
+			directed: true,
+		},
+		"directed edge in undirected document": {
+			doc: `<graphml><graph edgedefault="undirected"><edge source="a" target="b" directed="true"/></graph></graphml>`,
+		},
+		"invalid weight": {
+			doc: `<graphml><key id="w" for="edge" attr.name="weight" attr.type="double"/>` +
+				`<graph edgedefault="undirected"><edge source="a" target="b"><data key="w">x</data></edge></graph></graphml>`,
+		},
+		"invalid typed value": {
+			doc: `<graphml><key id="k" for="node" attr.name="size" attr.type="int"/>` +
+				`<graph edgedefault="undirected"><node id="a"><data key="k">1.5</data></node></graph></graphml>`,
+		},
+		"multiple graphs": {
+			doc: `<graphml><graph edgedefault="undirected"/><graph edgedefault="undirected"/></graphml>`,
+		},
+		"hyperedge": {
+			doc: `<graphml><graph edgedefault="undirected"><hyperedge/></graph></graphml>`,
+		},
+		"malformed xml": {
+			doc: `<graphml><graph>`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if test.directed {
+				_, err = ReadDirGraphML(strings.NewReader(test.doc))
+			} else {
+				_, err = ReadGraphML(strings.NewReader(test.doc))
+			}
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	g, _ := NewGraph("test")
+	g.AddEdge("b", "a", 2.5)
+	g.AddEdge("a", "c")
+	g.AddNode("d")
+	g.SetNodeAttr("a", "label", "first")
+	g.SetNodeAttr("d", "label", 7)
+	g.SetEdgeAttr("a", "b", "count", 3)
+
+	var buf bytes.Buffer
+	err := g.WriteGraphML(&buf)
+	assert.Nil(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="weight" for="edge" attr.name="weight" attr.type="double"></key>
+  <key id="d0" for="node" attr.name="label" attr.type="string"></key>
+  <key id="d1" for="edge" attr.name="count" attr.type="long"></key>
+  <graph id="test" edgedefault="undirected">
+    <node id="a">
+      <data key="d0">first</data>
+    </node>
+    <node id="b"></node>
+    <node id="c"></node>
+    <node id="d">
+      <data key="d0">7</data>
+    </node>
+    <edge id="e0" source="a" target="b">
+      <data key="weight">2.5</data>
+      <data key="d1">3</data>
+    </edge>
+    <edge id="e1" source="a" target="c">
+      <data key="weight">1</data>
+    </edge>
+  </graph>
+</graphml>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestGraphMLRoundTrip(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		tg := setupTestGraph()
+		tg.g.AddNode("e")
+		tg.g.SetNodeAttr("a", "active", true)
+		tg.g.SetEdgeAttr("c", "d", "length", 0.5)
+
+		var buf bytes.Buffer
+		assert.Nil(t, tg.g.WriteGraphML(&buf))
+		g, err := ReadGraphML(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, tg.g.Name, g.Name)
+		assert.Equal(t, tg.g.dirAdj, g.dirAdj)
+		assert.Equal(t, tg.g.nodeAttrs, g.nodeAttrs)
+		assert.Equal(t, tg.g.edgeAttrs, g.edgeAttrs)
+	})
+	t.Run("directed", func(t *testing.T) {
+		tdg := setupTestDirGraph()
+		tdg.dg.SetEdgeAttr("a", "b", "label", "x")
+
+		var buf bytes.Buffer
+		assert.Nil(t, tdg.dg.WriteGraphML(&buf))
+		dg, err := ReadDirGraphML(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, tdg.dg.dirAdj, dg.dirAdj)
+		assert.Equal(t, tdg.dg.invAdj, dg.invAdj)
+		assert.Equal(t, tdg.dg.edgeAttrs, dg.edgeAttrs)
+		assert.Equal(t, tdg.dg.invEdgeAttrs, dg.invEdgeAttrs)
+	})
+}
+
+func TestWriteGraphMLWeightAttribute(t *testing.T) {
+	g, _ := NewGraph("test")
+	g.AddEdge("a", "b")
+	g.SetEdgeAttr("a", "b", "weight", 3)
+	err := g.WriteGraphML(&bytes.Buffer{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"io"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// ReadOnlyGraphOf is a graph whose nodes and weighted outgoing edges can be queried, which is the
+// interface required by algorithms that traverse a graph; an undirected graph has an outgoing
+// edge in both directions between each pair of connected nodes
+type ReadOnlyGraphOf[T comparable] interface {
+	Directed() bool
+	GetNodes() []T
+	HasNode(T) bool
+	HasEdge(src T, tgt T) bool
+	GetNeighbors(T) (map[T]float64, bool)
+}
+
+// ReadOnlyGraph is a ReadOnlyGraphOf with string nodes
+type ReadOnlyGraph = ReadOnlyGraphOf[n.Node]
+
+// DirectedGraphOf is a graph whose incoming edges can also be queried; an undirected
+// graph satisfies it with the incoming edges of each node equal to its outgoing edges
+type DirectedGraphOf[T comparable] interface {
+	ReadOnlyGraphOf[T]
+	GetInvNeighbors(T) (map[T]float64, bool)
+}
+
+// DirectedGraph is a DirectedGraphOf with string nodes
+type DirectedGraph = DirectedGraphOf[n.Node]
+
+// WeightedGraphOf is a graph whose edge weights and weighted degrees can be queried
+type WeightedGraphOf[T comparable] interface {
+	ReadOnlyGraphOf[T]
+	GetEdgeWeight(src T, tgt T) (float64, bool)
+	GetOutDegree(T) (float64, bool)
+	GetInDegree(T) (float64, bool)
+	GetTotalDegree(T) (float64, bool)
+}
+
+// WeightedGraph is a WeightedGraphOf with string nodes
+type WeightedGraph = WeightedGraphOf[n.Node]
+
+// NeighborRangerOf is a graph that can iterate over the outgoing edges of a node without allocating a map;
+// an implementation may hold a lock while calling f so f must not call any method of the graph
+type NeighborRangerOf[T comparable] interface {
+	RangeNeighbors(node T, f func(nbr T, wgt float64) bool) bool
+}
+
+// NeighborRanger is a NeighborRangerOf with string nodes
+type NeighborRanger = NeighborRangerOf[n.Node]
+
+// RangeNeighbors calls f for each node that a node has an edge to and the weight of the edge, stopping if f
+// returns false, and returns false if the node is not found; it uses the RangeNeighbors method of a graph
+// that is a NeighborRangerOf and iterates over the map returned by GetNeighbors otherwise, so f must not
+// call any method of the graph
+func RangeNeighbors[T comparable](g ReadOnlyGraphOf[T], node T, f func(nbr T, wgt float64) bool) bool {
+	if r, ok := g.(NeighborRangerOf[T]); ok {
+		return r.RangeNeighbors(node, f)
+	}
+	nbrs, ok := g.GetNeighbors(node)
+	for nbr, wgt := range nbrs {
+		if !f(nbr, wgt) {
+			break
+		}
+	}
+	return ok
+}
+
+// MutableGraphOf is a graph whose nodes and edges can be added and removed
+type MutableGraphOf[T comparable] interface {
+	ReadOnlyGraphOf[T]
+	AddNode(T)
+	AddEdge(src T, tgt T, weight ...float64)
+	RemoveEdge(src T, tgt T)
+	RemoveNode(T)
+}
+
+// MutableGraph is a MutableGraphOf with string nodes
+type MutableGraph = MutableGraphOf[n.Node]
+
+// AttributedGraph is a graph with node and edge attributes
+type AttributedGraph interface {
+	SetNodeAttr(node n.Node, key string, value interface{})
+	GetNodeAttrs(n.Node) (Attributes, bool)
+	DeleteNodeAttr(node n.Node, key string)
+	SetEdgeAttr(src n.Node, tgt n.Node, key string, value interface{}) bool
+	GetEdgeAttrs(src n.Node, tgt n.Node) (Attributes, bool)
+	DeleteEdgeAttr(src n.Node, tgt n.Node, key string)
+}
+
+// fullGraph is the set of all methods shared by Graph and DirGraph
+type fullGraph interface {
+	DirectedGraph
+	WeightedGraph
+	MutableGraph
+	AttributedGraph
+	WriteEdgeList(io.Writer) error
+}
+
+var (
+	_ DirectedGraphOf[int] = (*GraphOf[int])(nil)
+	_ WeightedGraphOf[int] = (*GraphOf[int])(nil)
+	_ MutableGraphOf[int]  = (*GraphOf[int])(nil)
+	_ DirectedGraphOf[int] = (*DirGraphOf[int])(nil)
+	_ WeightedGraphOf[int] = (*DirGraphOf[int])(nil)
+	_ MutableGraphOf[int]  = (*DirGraphOf[int])(nil)
+
+	_ fullGraph = (*Graph)(nil)
+	_ fullGraph = (*DirGraph)(nil)
+	_ fullGraph = (*ConcurrentGraph)(nil)
+	_ fullGraph = (*ConcurrentDirGraph)(nil)
+
+	_ DirectedGraph  = (*CompactGraph)(nil)
+	_ WeightedGraph  = (*CompactGraph)(nil)
+	_ NeighborRanger = (*CompactGraph)(nil)
+	_ NeighborRanger = (*ConcurrentGraph)(nil)
+	_ NeighborRanger = (*ConcurrentDirGraph)(nil)
+)
@@ -0,0 +1,53 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirected(t *testing.T) {
+	tests := map[string]struct {
+		g        ReadOnlyGraph
+		expected bool
+	}{
+		"graph": {
+			g:        setupTestGraph().g,
+			expected: false,
+		},
+		"directed graph": {
+			g:        setupTestDirGraph().dg,
+			expected: true,
+		},
+		"concurrent graph": {
+			g:        func() ReadOnlyGraph { cg, _ := NewConcurrentGraph("test"); return cg }(),
+			expected: false,
+		},
+		"concurrent directed graph": {
+			g:        func() ReadOnlyGraph { cdg, _ := NewConcurrentDirGraph("test"); return cdg }(),
+			expected: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.g.Directed())
+		})
+	}
+}
+
+func TestMutableGraph(t *testing.T) {
+	for name, g := range map[string]MutableGraph{
+		"graph":          setupTestGraph().g,
+		"directed graph": setupTestDirGraph().dg,
+	} {
+		t.Run(name, func(t *testing.T) {
+			g.AddEdge("x", "y", 2)
+			assert.True(t, g.HasEdge("x", "y"))
+			assert.Equal(t, !g.Directed(), g.HasEdge("y", "x"))
+			g.RemoveNode("x")
+			assert.False(t, g.HasNode("x"))
+			assert.True(t, g.HasNode("y"))
+		})
+	}
+}
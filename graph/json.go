@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+const (
+	jsonNodeIDKey = "id"
+	jsonSourceKey = "source"
+	jsonTargetKey = "target"
+	jsonWeightKey = "weight"
+)
+
+// nodeLinkJSON is the node-link format used by NetworkX and D3, in which the attributes of each
+// node and edge are members of its object alongside its id or its source, target and weight
+type nodeLinkJSON struct {
+	Name       string                   `json:"name"`
+	Directed   *bool                    `json:"directed,omitempty"`
+	MultiGraph bool                     `json:"multigraph"`
+	Graph      map[string]interface{}   `json:"graph,omitempty"`
+	Nodes      []map[string]interface{} `json:"nodes"`
+	Edges      []map[string]interface{} `json:"edges"`
+	Links      []map[string]interface{} `json:"links,omitempty"`
+}
+
+// MarshalJSON encodes a Graph in the node-link format with each undirected edge encoded once
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return g.marshalNodeLink(g.GetNodes(), false)
+}
+
+// UnmarshalJSON decodes an undirected graph in the node-link format into a Graph, replacing its contents
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	decoded := newGraph("")
+	if err := decoded.unmarshalNodeLink(data, false); err != nil {
+		return err
+	}
+	*g = *decoded
+	return nil
+}
+
+// MarshalJSON encodes a DirGraph in the node-link format
+func (dg *DirGraph) MarshalJSON() ([]byte, error) {
+	return dg.marshalNodeLink(dg.GetNodes(), true)
+}
+
+// UnmarshalJSON decodes a directed graph in the node-link format into a DirGraph, replacing its contents
+func (dg *DirGraph) UnmarshalJSON(data []byte) error {
+	decoded := newDirGraph("")
+	if err := decoded.unmarshalNodeLink(data, true); err != nil {
+		return err
+	}
+	*dg = *decoded
+	return nil
+}
+
+func (g *Graph) marshalNodeLink(nodes []n.Node, directed bool) ([]byte, error) {
+	nl := nodeLinkJSON{
+		Name:     g.Name,
+		Directed: &directed,
+		Graph:    map[string]interface{}{"name": g.Name},
+		Nodes:    []map[string]interface{}{},
+		Edges:    []map[string]interface{}{},
+	}
+
+	sortNodes(nodes)
+	for _, node := range nodes {
+		obj := map[string]interface{}{}
+		for key, val := range g.nodeAttrs[node] {
+			obj[key] = val
+		}
+		if _, ok := obj[jsonNodeIDKey]; ok {
+			return nil, fmt.Errorf("node %s has an attribute that conflicts with its id", node)
+		}
+		obj[jsonNodeIDKey] = node
+		nl.Nodes = append(nl.Nodes, obj)
+	}
+
+	for _, e := range g.sortedEdges(nodes, directed) {
+		obj := map[string]interface{}{}
+		attrs, _ := g.edgeAttrs.get(e.src, e.tgt)
+		for key, val := range attrs {
+			obj[key] = val
+		}
+		for _, key := range []string{jsonSourceKey, jsonTargetKey, jsonWeightKey} {
+			if _, ok := obj[key]; ok {
+				return nil, fmt.Errorf("edge %s %s has an attribute that conflicts with its %s", e.src, e.tgt, key)
+			}
+		}
+		obj[jsonSourceKey] = e.src
+		obj[jsonTargetKey] = e.tgt
+		obj[jsonWeightKey] = e.wgt
+		nl.Edges = append(nl.Edges, obj)
+	}
+	return json.Marshal(nl)
+}
+
+// unmarshalNodeLink decodes the node-link format, also accepting the "links" member used by D3 and older
+// versions of NetworkX for edges and the name member of the "graph" object for the name
+func (g *Graph) unmarshalNodeLink(data []byte, directed bool) error {
+	nl := nodeLinkJSON{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&nl); err != nil {
+		return err
+	}
+	if nl.Directed != nil && *nl.Directed != directed {
+		return fmt.Errorf("node-link graph has directed %t, expected %t", *nl.Directed, directed)
+	}
+	if nl.MultiGraph {
+		return fmt.Errorf("node-link multigraphs are not supported")
+	}
+
+	g.Name = nl.Name
+	if name, ok := nl.Graph["name"].(string); ok && g.Name == "" {
+		g.Name = name
+	}
+
+	for i, obj := range nl.Nodes {
+		node, err := jsonNodeID(obj[jsonNodeIDKey])
+		if err != nil {
+			return fmt.Errorf("node %d: %v", i, err)
+		}
+		g.AddNode(node)
+		for key, val := range obj {
+			if key != jsonNodeIDKey {
+				g.SetNodeAttr(node, key, jsonAttrValue(val))
+			}
+		}
+	}
+
+	for i, obj := range append(nl.Edges, nl.Links...) {
+		src, err := jsonNodeID(obj[jsonSourceKey])
+		if err != nil {
+			return fmt.Errorf("edge %d source: %v", i, err)
+		}
+		tgt, err := jsonNodeID(obj[jsonTargetKey])
+		if err != nil {
+			return fmt.Errorf("edge %d target: %v", i, err)
+		}
+		wgt := defaultWgt
+		if val, ok := obj[jsonWeightKey]; ok {
+			num, isNum := val.(json.Number)
+			if !isNum {
+				return fmt.Errorf("edge %d: invalid weight %v", i, val)
+			}
+			if wgt, err = num.Float64(); err != nil {
+				return fmt.Errorf("edge %d: invalid weight %v", i, val)
+			}
+		}
+		g.AddEdge(src, tgt, wgt)
+		for key, val := range obj {
+			if key != jsonSourceKey && key != jsonTargetKey && key != jsonWeightKey {
+				g.SetEdgeAttr(src, tgt, key, jsonAttrValue(val))
+			}
+		}
+	}
+	return nil
+}
+
+// jsonNodeID converts a string or numeric JSON node id to a node
+func jsonNodeID(val interface{}) (n.Node, error) {
+	switch id := val.(type) {
+	case string:
+		return n.Node(id), nil
+	case json.Number:
+		return n.Node(id.String()), nil
+	case nil:
+		return "", fmt.Errorf("missing node id")
+	}
+	return "", fmt.Errorf("invalid node id %v", val)
+}
+
+// jsonAttrValue converts a decoded JSON number to an int if it is integral and a float64 otherwise
+func jsonAttrValue(val interface{}) interface{} {
+	num, ok := val.(json.Number)
+	if !ok {
+		return val
+	}
+	if i, err := strconv.Atoi(num.String()); err == nil {
+		return i
+	}
+	f, _ := num.Float64()
+	return f
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func TestGraphMarshalJSON(t *testing.T) {
+	t.Run("undirected graph encodes each edge once", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("b", "a", 1.5)
+		g.AddNode("c")
+		g.SetNodeAttr("a", "label", "first")
+		g.SetEdgeAttr("a", "b", "count", 2)
+
+		data, err := json.Marshal(g)
+		assert.Nil(t, err)
+		expected := `{"name":"test","directed":false,"multigraph":false,"graph":{"name":"test"},` +
+			`"nodes":[{"id":"a","label":"first"},{"id":"b"},{"id":"c"}],` +
+			`"edges":[{"count":2,"source":"a","target":"b","weight":1.5}]}`
+		assert.JSONEq(t, expected, string(data))
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		dg, _ := NewDirGraph("test")
+		dg.AddEdge("b", "a", 1.5)
+		dg.AddEdge("a", "b")
+
+		data, err := json.Marshal(dg)
+		assert.Nil(t, err)
+		expected := `{"name":"test","directed":true,"multigraph":false,"graph":{"name":"test"},` +
+			`"nodes":[{"id":"a"},{"id":"b"}],` +
+			`"edges":[{"source":"a","target":"b","weight":1},{"source":"b","target":"a","weight":1.5}]}`
+		assert.JSONEq(t, expected, string(data))
+	})
+	t.Run("attribute conflicting with edge member should error", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+		g.SetEdgeAttr("a", "b", "source", "log")
+		_, err := json.Marshal(g)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGraphUnmarshalJSON(t *testing.T) {
+	t.Run("networkx node-link data", func(t *testing.T) {
+		data := `{"directed": false, "multigraph": false, "graph": {"name": "nx"},
+			"nodes": [{"id": 1, "size": 3}, {"id": 2, "ratio": 0.5}, {"id": "x"}],
+			"links": [{"source": 1, "target": 2, "weight": 4, "kind": "friend"}, {"source": 2, "target": 3}]}`
+		g := &Graph{}
+		err := json.Unmarshal([]byte(data), g)
+		assert.Nil(t, err)
+		assert.Equal(t, "nx", g.Name)
+		assert.ElementsMatch(t, []n.Node{"1", "2", "3", "x"}, g.GetNodes())
+
+		wgt, _ := g.GetEdgeWeight("2", "1")
+		assert.Equal(t, 4.0, wgt)
+		wgt, _ = g.GetEdgeWeight("3", "2")
+		assert.Equal(t, 1.0, wgt)
+
+		attrs, _ := g.GetNodeAttrs("1")
+		assert.Equal(t, Attributes{"size": 3}, attrs)
+		attrs, _ = g.GetNodeAttrs("2")
+		assert.Equal(t, Attributes{"ratio": 0.5}, attrs)
+		attrs, _ = g.GetEdgeAttrs("2", "1")
+		assert.Equal(t, Attributes{"kind": "friend"}, attrs)
+	})
+	t.Run("unmarshal replaces existing contents", func(t *testing.T) {
+		g := setupTestGraph().g
+		err := json.Unmarshal([]byte(`{"name": "new", "nodes": [{"id": "z"}], "edges": []}`), g)
+		assert.Nil(t, err)
+		assert.Equal(t, "new", g.Name)
+		assert.Equal(t, []n.Node{"z"}, g.GetNodes())
+	})
+}
+
+func TestGraphUnmarshalJSONErrors(t *testing.T) {
+	tests := map[string]struct {
+		data     string
+		directed bool
+	}{
+		"directed data into undirected graph": {
+			data: `{"directed": true, "nodes": [], "edges": []}`,
+		},
+		"undirected data into directed graph": {
+			data:     `{"directed": false, "nodes": [], "edges": []}`,
+			directed: true,
+		},
+		"multigraph": {
+			data: `{"multigraph": true, "nodes": [], "edges": []}`,
+		},
+		"missing node id": {
+			data: `{"nodes": [{"label": "a"}]}`,
+		},
+		"invalid edge target": {
+			data: `{"edges": [{"source": "a", "target": ["b"]}]}`,
+		},
+		"invalid weight": {
+			data: `{"edges": [{"source": "a", "target": "b", "weight": "heavy"}]}`,
+		},
+		"malformed json": {
+			data: `{"nodes": [`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if test.directed {
+				err = json.Unmarshal([]byte(test.data), &DirGraph{})
+			} else {
+				err = json.Unmarshal([]byte(test.data), &Graph{})
+			}
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		tg := setupTestGraph()
+		tg.g.AddNode("e")
+		tg.g.SetNodeAttr("a", "active", true)
+		tg.g.SetEdgeAttr("c", "d", "count", 3)
+
+		data, err := json.Marshal(tg.g)
+		assert.Nil(t, err)
+		g := &Graph{}
+		assert.Nil(t, json.Unmarshal(data, g))
+		assert.Equal(t, tg.g.Name, g.Name)
+		assert.Equal(t, tg.g.dirAdj, g.dirAdj)
+		assert.Equal(t, tg.g.nodeAttrs, g.nodeAttrs)
+		assert.Equal(t, tg.g.edgeAttrs, g.edgeAttrs)
+	})
+	t.Run("directed", func(t *testing.T) {
+		tdg := setupTestDirGraph()
+		tdg.dg.SetEdgeAttr("a", "b", "label", "x")
+
+		data, err := json.Marshal(tdg.dg)
+		assert.Nil(t, err)
+		dg := &DirGraph{}
+		assert.Nil(t, json.Unmarshal(data, dg))
+		assert.Equal(t, tdg.dg.Name, dg.Name)
+		assert.Equal(t, tdg.dg.dirAdj, dg.dirAdj)
+		assert.Equal(t, tdg.dg.invAdj, dg.invAdj)
+		assert.Equal(t, tdg.dg.invEdgeAttrs, dg.invEdgeAttrs)
+	})
+}
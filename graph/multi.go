@@ -0,0 +1,317 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// EdgeID uniquely identifies an edge of a multigraph
+type EdgeID int
+
+// MultiEdge is one of possibly many parallel edges between two nodes of a multigraph
+type MultiEdge struct {
+	ID     EdgeID
+	Src    n.Node
+	Tgt    n.Node
+	Weight float64
+}
+
+// Aggregation is a method for combining the weights of parallel edges into a single weight
+type Aggregation int
+
+const (
+	// SumWeights combines parallel edges into an edge weighted by the sum of their weights
+	SumWeights Aggregation = iota
+	// MinWeight combines parallel edges into an edge weighted by the minimum of their weights
+	MinWeight
+	// MaxWeight combines parallel edges into an edge weighted by the maximum of their weights
+	MaxWeight
+	// MeanWeight combines parallel edges into an edge weighted by the mean of their weights
+	MeanWeight
+)
+
+func (agg Aggregation) validate() error {
+	if agg < SumWeights || agg > MeanWeight {
+		return fmt.Errorf("unknown aggregation %d", agg)
+	}
+	return nil
+}
+
+func (agg Aggregation) aggregate(weights map[EdgeID]float64) float64 {
+	var result float64
+	first := true
+	for _, w := range weights {
+		switch agg {
+		case SumWeights, MeanWeight:
+			result += w
+		case MinWeight:
+			if first || w < result {
+				result = w
+			}
+		case MaxWeight:
+			if first || w > result {
+				result = w
+			}
+		}
+		first = false
+	}
+	if agg == MeanWeight {
+		result /= float64(len(weights))
+	}
+	return result
+}
+
+type multiAdj map[n.Node]map[n.Node]map[EdgeID]float64
+
+func (a multiAdj) addNode(node n.Node) {
+	if _, ok := a[node]; ok {
+		return
+	}
+	a[node] = map[n.Node]map[EdgeID]float64{}
+}
+
+func (a multiAdj) addDirectedEdge(src n.Node, tgt n.Node, id EdgeID, wgt float64) {
+	a.addNode(src)
+	if _, ok := a[src][tgt]; !ok {
+		a[src][tgt] = map[EdgeID]float64{}
+	}
+	a[src][tgt][id] = wgt
+}
+
+// removeDirectedEdge removes an edge but keeps its src node even if it no longer has neighbors
+func (a multiAdj) removeDirectedEdge(src n.Node, tgt n.Node, id EdgeID) {
+	edges, ok := a[src][tgt]
+	if !ok {
+		return
+	}
+	delete(edges, id)
+	if len(edges) == 0 {
+		delete(a[src], tgt)
+	}
+}
+
+func (a multiAdj) getSrcNodes() (nodes []n.Node) {
+	for node := range a {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (a multiAdj) hasSrcNode(node n.Node) bool {
+	_, ok := a[node]
+	return ok
+}
+
+// MultiGraph is an adjacency map representation of an undirected graph that allows parallel edges
+type MultiGraph struct {
+	*multiAdj
+	Name   string
+	invAdj *multiAdj
+
+	edges  map[EdgeID]MultiEdge
+	nextID EdgeID
+}
+
+// NewMultiGraph creates a new undirected multigraph
+func NewMultiGraph(name string, readers ...io.ReadCloser) (*MultiGraph, error) {
+	mg := &MultiGraph{
+		multiAdj: &multiAdj{},
+		Name:     name,
+		edges:    map[EdgeID]MultiEdge{},
+	}
+	// undirected multigraph has a symmetric adjacency structure so the
+	// inverse adjacency is just a pointer to the adjacency map
+	mg.invAdj = mg.multiAdj
+
+	for _, r := range readers {
+		err := mg.addFromReader(r)
+		if err != nil {
+			return mg, err
+		}
+	}
+	return mg, nil
+}
+
+func (mg *MultiGraph) addFromReader(r io.ReadCloser) error {
+	// attributes are not supported by multigraphs so the reader does not parse them
+	addNode := func(node n.Node, _ map[string]string) error {
+		mg.AddNode(node)
+		return nil
+	}
+	addEdge := func(src n.Node, tgt n.Node, wgt float64, _ map[string]string) error {
+		mg.AddEdge(src, tgt, wgt)
+		return nil
+	}
+	return EdgeListReader{}.read(r, &EdgeListReport{}, addNode, addEdge)
+}
+
+// AddNode adds a node without any edges if the node does not already exist
+func (mg *MultiGraph) AddNode(node n.Node) {
+	mg.addNode(node)
+	mg.invAdj.addNode(node)
+}
+
+// AddEdge adds an edge between two nodes with an optional weight that defaults to 1.0, keeping
+// any existing edges between the nodes, and returns the ID of the new edge
+func (mg *MultiGraph) AddEdge(src n.Node, tgt n.Node, weight ...float64) EdgeID {
+	wgt := defaultWgt
+	if len(weight) > 0 {
+		wgt = weight[0]
+	}
+	id := mg.nextID
+	mg.nextID++
+
+	mg.addDirectedEdge(src, tgt, id, wgt)
+	mg.invAdj.addDirectedEdge(tgt, src, id, wgt)
+	// both nodes are kept in both adjacencies so that either holds all nodes of a directed multigraph
+	mg.addNode(tgt)
+	mg.invAdj.addNode(src)
+
+	mg.edges[id] = MultiEdge{ID: id, Src: src, Tgt: tgt, Weight: wgt}
+	return id
+}
+
+// RemoveEdge removes a single edge by its ID, returning false if no edge has the ID
+func (mg *MultiGraph) RemoveEdge(id EdgeID) bool {
+	e, ok := mg.edges[id]
+	if !ok {
+		return false
+	}
+	mg.removeDirectedEdge(e.Src, e.Tgt, id)
+	mg.invAdj.removeDirectedEdge(e.Tgt, e.Src, id)
+	delete(mg.edges, id)
+	return true
+}
+
+// RemoveEdges removes all parallel edges between two nodes, keeping both nodes in the graph
+func (mg *MultiGraph) RemoveEdges(src n.Node, tgt n.Node) {
+	for _, e := range mg.GetEdges(src, tgt) {
+		mg.RemoveEdge(e.ID)
+	}
+}
+
+// RemoveNode removes a node entirely from a MultiGraph such that
+// no edges exist between it and any other node
+func (mg *MultiGraph) RemoveNode(node n.Node) {
+	for _, edges := range (*mg.multiAdj)[node] {
+		for id := range edges {
+			mg.RemoveEdge(id)
+		}
+	}
+	for _, edges := range (*mg.invAdj)[node] {
+		for id := range edges {
+			mg.RemoveEdge(id)
+		}
+	}
+	delete(*mg.multiAdj, node)
+	delete(*mg.invAdj, node)
+}
+
+// GetNodes gets a slice of all nodes in a MultiGraph
+func (mg *MultiGraph) GetNodes() []n.Node {
+	// every node is a src node since nodes are added to both adjacencies
+	return mg.getSrcNodes()
+}
+
+// HasNode returns true if the multigraph contains the specified node
+func (mg *MultiGraph) HasNode(node n.Node) bool {
+	return mg.hasSrcNode(node)
+}
+
+// HasEdge returns true if at least one edge exists from a node to another node, false otherwise
+func (mg *MultiGraph) HasEdge(src n.Node, tgt n.Node) bool {
+	_, ok := (*mg.multiAdj)[src][tgt]
+	return ok
+}
+
+// GetEdge gets an edge by its ID
+func (mg *MultiGraph) GetEdge(id EdgeID) (MultiEdge, bool) {
+	e, ok := mg.edges[id]
+	return e, ok
+}
+
+// GetEdges gets all parallel edges from a node to another node ordered by ID; edges of an
+// undirected multigraph are returned in the direction in which they were added
+func (mg *MultiGraph) GetEdges(src n.Node, tgt n.Node) []MultiEdge {
+	edges := []MultiEdge{}
+	for id := range (*mg.multiAdj)[src][tgt] {
+		edges = append(edges, mg.edges[id])
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// GetAllEdges gets every edge of the multigraph ordered by ID
+func (mg *MultiGraph) GetAllEdges() []MultiEdge {
+	edges := make([]MultiEdge, 0, len(mg.edges))
+	for _, e := range mg.edges {
+		edges = append(edges, e)
+	}
+	sortEdges(edges)
+	return edges
+}
+
+// Collapse creates a graph with a single edge between each pair of nodes connected in the multigraph,
+// weighted by aggregating the weights of the parallel edges between them
+func (mg *MultiGraph) Collapse(agg Aggregation) (*Graph, error) {
+	g, _ := NewGraph(mg.Name) // no need to check error since there are no readers
+	err := mg.collapseInto(g.AddNode, g.AddEdge, agg)
+	return g, err
+}
+
+// collapseInto adds each node and the aggregate of each set of parallel edges using the specified adders
+func (mg *MultiGraph) collapseInto(
+	addNode func(n.Node),
+	addEdge func(n.Node, n.Node, ...float64),
+	agg Aggregation,
+) error {
+	if err := agg.validate(); err != nil {
+		return err
+	}
+	for src, nbrs := range *mg.multiAdj {
+		addNode(src)
+		for tgt, edges := range nbrs {
+			addEdge(src, tgt, agg.aggregate(edges))
+		}
+	}
+	return nil
+}
+
+func sortEdges(edges []MultiEdge) {
+	sort.Slice(edges, func(i, j int) bool { return edges[i].ID < edges[j].ID })
+}
+
+// MultiDirGraph is an adjacency map representation of a directed graph that allows parallel edges
+type MultiDirGraph struct {
+	MultiGraph
+}
+
+// NewMultiDirGraph creates a new directed multigraph
+func NewMultiDirGraph(name string, readers ...io.ReadCloser) (*MultiDirGraph, error) {
+	mdg := &MultiDirGraph{
+		MultiGraph{
+			multiAdj: &multiAdj{},
+			Name:     name,
+			invAdj:   &multiAdj{},
+			edges:    map[EdgeID]MultiEdge{},
+		},
+	}
+	for _, r := range readers {
+		err := mdg.addFromReader(r)
+		if err != nil {
+			return mdg, err
+		}
+	}
+	return mdg, nil
+}
+
+// Collapse creates a directed graph with a single edge from each node to each node it connects to in the
+// multigraph, weighted by aggregating the weights of the parallel edges between them
+func (mdg *MultiDirGraph) Collapse(agg Aggregation) (*DirGraph, error) {
+	dg, _ := NewDirGraph(mdg.Name) // no need to check error since there are no readers
+	err := mdg.collapseInto(dg.AddNode, dg.AddEdge, agg)
+	return dg, err
+}
@@ -0,0 +1,246 @@
+package graph
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupTestMultiGraph() *MultiGraph {
+	edges := []byte("a b 1.5\na b 2.5\nb a 4\nc d 1\nc d 1.1\nd d 3\ne")
+	reader := ioutil.NopCloser(bytes.NewReader(edges))
+	mg, _ := NewMultiGraph("test", reader)
+	return mg
+}
+
+func setupTestMultiDirGraph() *MultiDirGraph {
+	edges := []byte("a b 1.5\na b 2.5\nb a 4\nc d 1\nc d 1.1\nd d 3\ne")
+	reader := ioutil.NopCloser(bytes.NewReader(edges))
+	mdg, _ := NewMultiDirGraph("test", reader)
+	return mdg
+}
+
+func edgeWeights(edges []MultiEdge) []float64 {
+	weights := []float64{}
+	for _, e := range edges {
+		weights = append(weights, e.Weight)
+	}
+	return weights
+}
+
+func TestNewMultiGraph(t *testing.T) {
+	t.Run("empty multigraph", func(t *testing.T) {
+		mg, err := NewMultiGraph("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "test", mg.Name)
+		assert.Empty(t, mg.GetNodes())
+		assert.Empty(t, mg.GetAllEdges())
+	})
+	t.Run("multigraph from reader keeps parallel edges", func(t *testing.T) {
+		mg := setupTestMultiGraph()
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d", "e"}, mg.GetNodes())
+		assert.Len(t, mg.GetAllEdges(), 6)
+		assert.Equal(t, []float64{1.5, 2.5, 4}, edgeWeights(mg.GetEdges("a", "b")))
+		assert.Equal(t, []float64{1.5, 2.5, 4}, edgeWeights(mg.GetEdges("b", "a")))
+		assert.Equal(t, []float64{1, 1.1}, edgeWeights(mg.GetEdges("c", "d")))
+	})
+	t.Run("attributes should error", func(t *testing.T) {
+		reader := ioutil.NopCloser(bytes.NewReader([]byte("a b color=red")))
+		_, err := NewMultiGraph("test", reader)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNewMultiDirGraph(t *testing.T) {
+	t.Run("empty multigraph", func(t *testing.T) {
+		mdg, err := NewMultiDirGraph("test")
+		assert.Nil(t, err)
+		assert.Equal(t, "test", mdg.Name)
+		assert.Empty(t, mdg.GetNodes())
+	})
+	t.Run("multigraph from reader keeps parallel edges in one direction", func(t *testing.T) {
+		mdg := setupTestMultiDirGraph()
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d", "e"}, mdg.GetNodes())
+		assert.Len(t, mdg.GetAllEdges(), 6)
+		assert.Equal(t, []float64{1.5, 2.5}, edgeWeights(mdg.GetEdges("a", "b")))
+		assert.Equal(t, []float64{4}, edgeWeights(mdg.GetEdges("b", "a")))
+		assert.Empty(t, mdg.GetEdges("d", "c"))
+		assert.True(t, mdg.HasNode("d"))
+	})
+}
+
+func TestMultiGraphAddEdge(t *testing.T) {
+	t.Run("each edge gets a distinct ID", func(t *testing.T) {
+		mg, _ := NewMultiGraph("test")
+		id1 := mg.AddEdge("a", "b")
+		id2 := mg.AddEdge("a", "b", 3)
+		assert.NotEqual(t, id1, id2)
+
+		e, ok := mg.GetEdge(id1)
+		assert.True(t, ok)
+		assert.Equal(t, MultiEdge{ID: id1, Src: "a", Tgt: "b", Weight: defaultWgt}, e)
+		e, ok = mg.GetEdge(id2)
+		assert.True(t, ok)
+		assert.Equal(t, MultiEdge{ID: id2, Src: "a", Tgt: "b", Weight: 3}, e)
+		assert.True(t, mg.HasEdge("b", "a"))
+	})
+}
+
+func TestMultiGraphRemoveEdge(t *testing.T) {
+	tests := map[string]struct {
+		mg             interface{}
+		src            n.Node
+		tgt            n.Node
+		remaining      []float64
+		reverseRemains []float64
+	}{
+		"remove one parallel edge from undirected multigraph": {
+			src:            "a",
+			tgt:            "b",
+			remaining:      []float64{2.5, 4},
+			reverseRemains: []float64{2.5, 4},
+		},
+		"remove only edge from undirected multigraph": {
+			src:            "d",
+			tgt:            "d",
+			remaining:      []float64{},
+			reverseRemains: []float64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mg := setupTestMultiGraph()
+			first := mg.GetEdges(test.src, test.tgt)[0]
+			assert.True(t, mg.RemoveEdge(first.ID))
+			assert.False(t, mg.RemoveEdge(first.ID))
+			_, ok := mg.GetEdge(first.ID)
+			assert.False(t, ok)
+			assert.Equal(t, test.remaining, edgeWeights(mg.GetEdges(test.src, test.tgt)))
+			assert.Equal(t, test.reverseRemains, edgeWeights(mg.GetEdges(test.tgt, test.src)))
+			assert.True(t, mg.HasNode(test.src))
+			assert.True(t, mg.HasNode(test.tgt))
+		})
+	}
+
+	t.Run("remove one parallel edge from directed multigraph", func(t *testing.T) {
+		mdg := setupTestMultiDirGraph()
+		first := mdg.GetEdges("a", "b")[0]
+		assert.True(t, mdg.RemoveEdge(first.ID))
+		assert.Equal(t, []float64{2.5}, edgeWeights(mdg.GetEdges("a", "b")))
+		assert.Equal(t, []float64{4}, edgeWeights(mdg.GetEdges("b", "a")))
+		assert.Len(t, (*mdg.invAdj)["b"]["a"], 1)
+	})
+}
+
+func TestMultiGraphRemoveEdges(t *testing.T) {
+	t.Run("remove all parallel edges", func(t *testing.T) {
+		mg := setupTestMultiGraph()
+		mg.RemoveEdges("b", "a")
+		assert.False(t, mg.HasEdge("a", "b"))
+		assert.False(t, mg.HasEdge("b", "a"))
+		assert.Len(t, mg.GetAllEdges(), 3)
+		assert.True(t, mg.HasNode("a"))
+	})
+}
+
+func TestMultiGraphRemoveNode(t *testing.T) {
+	tests := map[string]struct {
+		node          n.Node
+		expectedEdges int
+	}{
+		"remove nonexistent node": {
+			node:          "x",
+			expectedEdges: 6,
+		},
+		"remove node with parallel edges": {
+			node:          "a",
+			expectedEdges: 3,
+		},
+		"remove node with self loop": {
+			node:          "d",
+			expectedEdges: 3,
+		},
+		"remove isolated node": {
+			node:          "e",
+			expectedEdges: 6,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name+", undirected", func(t *testing.T) {
+			mg := setupTestMultiGraph()
+			mg.RemoveNode(test.node)
+			assert.False(t, mg.HasNode(test.node))
+			assert.Len(t, mg.GetAllEdges(), test.expectedEdges)
+			for _, nbrs := range *mg.multiAdj {
+				assert.NotContains(t, nbrs, test.node)
+			}
+		})
+		t.Run(name+", directed", func(t *testing.T) {
+			mdg := setupTestMultiDirGraph()
+			mdg.RemoveNode(test.node)
+			assert.False(t, mdg.HasNode(test.node))
+			assert.Len(t, mdg.GetAllEdges(), test.expectedEdges)
+			assert.NotContains(t, *mdg.invAdj, test.node)
+			for _, nbrs := range *mdg.invAdj {
+				assert.NotContains(t, nbrs, test.node)
+			}
+		})
+	}
+}
+
+func TestMultiGraphCollapse(t *testing.T) {
+	tests := map[string]struct {
+		agg        Aggregation
+		expectedAB float64
+		expectedCD float64
+	}{
+		"sum":  {agg: SumWeights, expectedAB: 8, expectedCD: 2.1},
+		"min":  {agg: MinWeight, expectedAB: 1.5, expectedCD: 1},
+		"max":  {agg: MaxWeight, expectedAB: 4, expectedCD: 1.1},
+		"mean": {agg: MeanWeight, expectedAB: 8.0 / 3, expectedCD: 1.05},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mg := setupTestMultiGraph()
+			g, err := mg.Collapse(test.agg)
+			assert.Nil(t, err)
+			assert.Equal(t, mg.Name, g.Name)
+			assert.ElementsMatch(t, mg.GetNodes(), g.GetNodes())
+			w, _ := g.GetEdgeWeight("b", "a")
+			assert.InEpsilon(t, test.expectedAB, w, float64EqualTol)
+			w, _ = g.GetEdgeWeight("d", "c")
+			assert.InEpsilon(t, test.expectedCD, w, float64EqualTol)
+			w, _ = g.GetEdgeWeight("d", "d")
+			assert.Equal(t, 3.0, w)
+		})
+	}
+
+	t.Run("unknown aggregation should error", func(t *testing.T) {
+		mg := setupTestMultiGraph()
+		_, err := mg.Collapse(Aggregation(-1))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMultiDirGraphCollapse(t *testing.T) {
+	t.Run("collapse keeps edge direction", func(t *testing.T) {
+		mdg := setupTestMultiDirGraph()
+		dg, err := mdg.Collapse(SumWeights)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, mdg.GetNodes(), dg.GetNodes())
+		w, _ := dg.GetEdgeWeight("a", "b")
+		assert.InEpsilon(t, 4, w, float64EqualTol)
+		w, _ = dg.GetEdgeWeight("b", "a")
+		assert.InEpsilon(t, 4, w, float64EqualTol)
+		assert.False(t, dg.HasEdge("d", "c"))
+		invNbrs, _ := dg.GetInvNeighbors("d")
+		assert.Contains(t, invNbrs, n.Node("c"))
+	})
+}
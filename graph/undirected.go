@@ -1,10 +1,9 @@
 package graph
 
 import (
-	"bufio"
+	"fmt"
 	"io"
-	"strconv"
-	"strings"
+	"math"
 
 	n "github.com/dkaslovsky/GoGraph/node"
 )
@@ -12,68 +11,99 @@ import (
 // defaultWgt is the weight applied to an edge when a weight is not specified
 const defaultWgt float64 = 1.0
 
-// Graph is a symmetric adjacency map representation of an undirected graph
-type Graph struct {
-	*dirAdj
+// GraphOf is a symmetric adjacency map representation of an undirected graph with nodes of any comparable type
+type GraphOf[T comparable] struct {
+	*dirAdj[T]
 	Name   string
-	invAdj *dirAdj
+	invAdj *dirAdj[T]
+}
+
+// Graph is a symmetric adjacency map representation of an undirected graph with string
+// nodes, which also holds node and edge attributes and can be read and written in text formats
+type Graph struct {
+	GraphOf[n.Node]
+
+	nodeAttrs    map[n.Node]Attributes
+	edgeAttrs    *attrAdj
+	invEdgeAttrs *attrAdj
 }
 
 // NewGraph creates a new undirected graph
 func NewGraph(name string, readers ...io.ReadCloser) (*Graph, error) {
-	g := &Graph{
-		dirAdj: &dirAdj{},
-		Name:   name,
-	}
+	g, _, err := EdgeListReader{}.NewGraph(name, readers...)
+	return g, err
+}
+
+// NewGraphOf creates a new undirected graph with nodes of any comparable type
+func NewGraphOf[T comparable](name string) *GraphOf[T] {
+	a := &dirAdj[T]{}
 	// undireted graph has a symmetric adjacency structure so the inverse adjacency
 	// (inverted index) is just a pointer to the adjacency map
-	g.invAdj = g.dirAdj
+	return &GraphOf[T]{
+		dirAdj: a,
+		Name:   name,
+		invAdj: a,
+	}
+}
 
-	for _, r := range readers {
-		err := g.addFromReader(r)
-		if err != nil {
-			return g, err
-		}
+func newGraph(name string) *Graph {
+	g := &Graph{
+		GraphOf:   *NewGraphOf[n.Node](name),
+		nodeAttrs: map[n.Node]Attributes{},
+		edgeAttrs: &attrAdj{},
 	}
-	return g, nil
+	g.invEdgeAttrs = g.edgeAttrs
+	return g
 }
 
-func (g *Graph) addFromReader(r io.ReadCloser) error {
-	defer r.Close()
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, " ")
-		if len(parts) < 2 {
-			continue
+func (g *Graph) addFromReader(r io.ReadCloser, elr EdgeListReader, report *EdgeListReport) error {
+	addNode := func(node n.Node, attrs map[string]string) error {
+		g.AddNode(node)
+		for key, val := range attrs {
+			g.SetNodeAttr(node, key, val)
 		}
-
-		src := n.Node(parts[0])
-		tgt := n.Node(parts[1])
-		if src == "" || tgt == "" {
-			continue
+		return nil
+	}
+	addEdge := func(src n.Node, tgt n.Node, wgt float64, attrs map[string]string) error {
+		report.Edges++
+		if src == tgt {
+			report.SelfLoops++
 		}
-
-		if len(parts) == 2 {
-			g.AddEdge(src, tgt)
-			continue
+		if prev, ok := g.GetEdgeWeight(src, tgt); ok {
+			report.Duplicates++
+			switch elr.Duplicates {
+			case FirstWins:
+				return nil
+			case SumDuplicates:
+				wgt += prev
+			case MaxDuplicate:
+				wgt = math.Max(wgt, prev)
+			case ErrorOnDuplicate:
+				return fmt.Errorf("duplicate edge %s %s", src, tgt)
+			}
 		}
-
-		weight, err := strconv.ParseFloat(parts[2], 64)
-		if err != nil {
-			return err
+		g.AddEdge(src, tgt, wgt)
+		for key, val := range attrs {
+			g.SetEdgeAttr(src, tgt, key, val)
 		}
-		g.AddEdge(src, tgt, weight)
+		return nil
 	}
+	return elr.read(r, report, addNode, addEdge)
+}
 
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-	return nil
+// Directed returns false since a Graph is undirected
+func (g *GraphOf[T]) Directed() bool {
+	return false
+}
+
+// AddNode adds a node without any edges if the node does not already exist
+func (g *GraphOf[T]) AddNode(node T) {
+	g.addNode(node)
+	g.invAdj.addNode(node)
 }
 
 // AddEdge adds an edge between two nodes with an optional weight that defaults to 1.0
-func (g *Graph) AddEdge(src n.Node, tgt n.Node, weight ...float64) {
+func (g *GraphOf[T]) AddEdge(src T, tgt T, weight ...float64) {
 	wgt := defaultWgt
 	if len(weight) > 0 {
 		wgt = weight[0]
@@ -82,53 +112,72 @@ func (g *Graph) AddEdge(src n.Node, tgt n.Node, weight ...float64) {
 	g.invAdj.addDirectedEdge(tgt, src, wgt)
 }
 
-// RemoveEdge removes an edge between two nodes
-func (g *Graph) RemoveEdge(src n.Node, tgt n.Node) {
+// RemoveEdge removes an edge between two nodes, keeping both nodes in the graph
+func (g *GraphOf[T]) RemoveEdge(src T, tgt T) {
 	g.removeDirectedEdge(src, tgt)
 	g.invAdj.removeDirectedEdge(tgt, src)
 }
 
+// RemoveEdge removes an edge between two nodes and its attributes, keeping both nodes in the graph
+func (g *Graph) RemoveEdge(src n.Node, tgt n.Node) {
+	g.GraphOf.RemoveEdge(src, tgt)
+	g.removeEdgeAttrs(src, tgt)
+}
+
 // RemoveNode removes a node entirely from a Graph such that
 // no edges exist between it an any other node
+func (g *GraphOf[T]) RemoveNode(node T) {
+	g.removeNode(node, g.RemoveEdge)
+}
+
+// RemoveNode removes a node and its attributes entirely from a Graph such that
+// no edges exist between it an any other node
 func (g *Graph) RemoveNode(node n.Node) {
+	g.removeNode(node, g.RemoveEdge)
+	delete(g.nodeAttrs, node)
+}
+
+// removeNode removes a node using a function that removes each of its edges
+func (g *GraphOf[T]) removeNode(node T, removeEdge func(src T, tgt T)) {
 	if nbrs, ok := g.GetNeighbors(node); ok {
 		for n := range nbrs {
-			g.RemoveEdge(node, n)
+			removeEdge(node, n)
 		}
 	}
+	g.removeSrcNode(node)
 }
 
 // PrintInv displays a Graph's incoming adjacency structure
-func (g *Graph) PrintInv() {
+func (g *GraphOf[T]) PrintInv() {
 	g.invAdj.Print()
 }
 
 // GetNodes gets a slice of all nodes in a Graph
-func (g *Graph) GetNodes() []n.Node {
+func (g *GraphOf[T]) GetNodes() []T {
 	return g.getSrcNodes()
 }
 
 // GetInvNeighbors gets a slice of nodes that have an edge from them to a specified node
-func (g *Graph) GetInvNeighbors(node n.Node) (map[n.Node]float64, bool) {
+func (g *GraphOf[T]) GetInvNeighbors(node T) (map[T]float64, bool) {
 	return g.invAdj.GetNeighbors(node)
 }
 
 // GetTotalDegree calculates the sum of weights of all edges with node as the source node
-func (g *Graph) GetTotalDegree(node n.Node) (float64, bool) {
+func (g *GraphOf[T]) GetTotalDegree(node T) (float64, bool) {
 	return g.GetOutDegree(node)
 }
 
 // GetDegree calculates the sum of weights of all edges with node as the source node
-func (g *Graph) GetDegree(node n.Node) (float64, bool) {
+func (g *GraphOf[T]) GetDegree(node T) (float64, bool) {
 	return g.GetOutDegree(node)
 }
 
 // GetInDegree calculates the sum of weights of all edges with node as the target node
-func (g *Graph) GetInDegree(node n.Node) (float64, bool) {
+func (g *GraphOf[T]) GetInDegree(node T) (float64, bool) {
 	return g.invAdj.GetOutDegree(node)
 }
 
 // HasNode returns true if the graph contains the specified node
-func (g *Graph) HasNode(node n.Node) bool {
+func (g *GraphOf[T]) HasNode(node T) bool {
 	return g.dirAdj.hasSrcNode(node)
 }
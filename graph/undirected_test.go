@@ -27,7 +27,7 @@ func setupTestGraph() *testGraph {
 }
 
 // symmetricExistsIn evaluates if a testEdge exists both as src->tgt and tgt->src in a dirAdj
-func (te testEdge) symmetricExistsIn(a dirAdj) bool {
+func (te testEdge) symmetricExistsIn(a dirAdj[n.Node]) bool {
 	return a.HasEdge(te.src, te.tgt) && a.HasEdge(te.tgt, te.src)
 }
 
@@ -63,6 +63,58 @@ func TestNewGraph(t *testing.T) {
 	})
 }
 
+func TestNewGraphIsolatedNodes(t *testing.T) {
+	t.Run("graph from reader with isolated nodes", func(t *testing.T) {
+		f := []byte("a b\nc\n\nd\na")
+		reader := ioutil.NopCloser(bytes.NewReader(f))
+		g, err := NewGraph("test", reader)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []n.Node{"a", "b", "c", "d"}, g.GetNodes())
+		assert.True(t, g.HasEdge("a", "b"))
+		for _, node := range []n.Node{"c", "d"} {
+			nbrs, ok := g.GetNeighbors(node)
+			assert.True(t, ok)
+			assert.Empty(t, nbrs)
+		}
+	})
+}
+
+func TestGraphAddNode(t *testing.T) {
+	tests := map[string]struct {
+		node         n.Node
+		expectedNbrs []n.Node
+	}{
+		"add new node": {
+			node:         "x",
+			expectedNbrs: []n.Node{},
+		},
+		"add existing node": {
+			node:         "b",
+			expectedNbrs: []n.Node{"a", "c"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tg := setupTestGraph()
+			g := tg.g
+			g.AddNode(test.node)
+			assert.True(t, g.HasNode(test.node))
+			nbrs, ok := g.GetNeighbors(test.node)
+			assert.True(t, ok)
+			assert.Len(t, nbrs, len(test.expectedNbrs))
+			for _, nbr := range test.expectedNbrs {
+				assert.Contains(t, nbrs, nbr)
+			}
+			deg, ok := g.GetDegree(test.node)
+			assert.True(t, ok)
+			if len(test.expectedNbrs) == 0 {
+				assert.Zero(t, deg)
+			}
+		})
+	}
+}
+
 func TestGraphAddEdgeDefaultWeight(t *testing.T) {
 	tests := map[string]testEdge{
 		"add edge to new nodes": {
@@ -188,6 +240,19 @@ func TestGraphRemoveEdge(t *testing.T) {
 	}
 }
 
+func TestGraphRemoveEdgeKeepsNodes(t *testing.T) {
+	t.Run("nodes remain after their only edge is removed", func(t *testing.T) {
+		g, _ := NewGraph("test")
+		g.AddEdge("a", "b")
+		g.RemoveEdge("a", "b")
+		assert.ElementsMatch(t, []n.Node{"a", "b"}, g.GetNodes())
+		assert.True(t, g.HasNode("a"))
+		assert.True(t, g.HasNode("b"))
+		assert.False(t, g.HasEdge("a", "b"))
+		assert.False(t, g.HasEdge("b", "a"))
+	})
+}
+
 func TestGraphRemoveNode(t *testing.T) {
 	tests := map[string]struct {
 		node n.Node
@@ -201,13 +266,18 @@ func TestGraphRemoveNode(t *testing.T) {
 		"remove existing node with self loop": {
 			node: "d",
 		},
+		"remove isolated node": {
+			node: "e",
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			tg := setupTestGraph()
 			g := tg.g
+			g.AddNode("e")
 			g.RemoveNode(test.node)
+			assert.False(t, g.HasNode(test.node))
 
 			assert.NotContains(t, *g.dirAdj, test.node)
 			for _, nbrs := range *g.dirAdj {
@@ -293,3 +363,40 @@ func TestGraphHasNode(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphOf(t *testing.T) {
+	type point struct{ x, y int }
+
+	t.Run("int nodes", func(t *testing.T) {
+		g := NewGraphOf[int64]("ints")
+		assert.Equal(t, "ints", g.Name)
+		assert.False(t, g.Directed())
+		g.AddEdge(1, 2, 1.5)
+		g.AddEdge(2, 3)
+		g.AddNode(4)
+
+		assert.ElementsMatch(t, []int64{1, 2, 3, 4}, g.GetNodes())
+		assert.True(t, g.HasEdge(2, 1))
+		wgt, ok := g.GetEdgeWeight(2, 1)
+		assert.True(t, ok)
+		assert.Equal(t, 1.5, wgt)
+		deg, _ := g.GetDegree(2)
+		assert.Equal(t, 2.5, deg)
+		invNbrs, _ := g.GetInvNeighbors(3)
+		assert.Equal(t, map[int64]float64{2: 1}, invNbrs)
+
+		g.RemoveNode(2)
+		assert.False(t, g.HasNode(2))
+		assert.False(t, g.HasEdge(1, 2))
+		nbrs, ok := g.GetNeighbors(1)
+		assert.True(t, ok)
+		assert.Empty(t, nbrs)
+	})
+	t.Run("struct nodes", func(t *testing.T) {
+		g := NewGraphOf[point]("points")
+		g.AddEdge(point{0, 0}, point{0, 1}, 2)
+		g.RemoveEdge(point{0, 1}, point{0, 0})
+		assert.False(t, g.HasEdge(point{0, 0}, point{0, 1}))
+		assert.True(t, g.HasNode(point{0, 1}))
+	})
+}
@@ -0,0 +1,159 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const marketBanner = "%%MatrixMarket"
+
+// WriteMatrixMarket writes a matrix as a real Matrix Market coordinate file with 1-based indices; a symmetric
+// matrix is written with only its lower triangular entries and an error is returned if it is not symmetric
+func WriteMatrixMarket(w io.Writer, c *COO, symmetric bool) error {
+	symmetry := "general"
+	if symmetric {
+		symmetry = "symmetric"
+	}
+
+	if err := c.check(); err != nil {
+		return err
+	}
+
+	entries := []int{}
+	if symmetric {
+		if err := c.checkSymmetric(); err != nil {
+			return err
+		}
+		for k := range c.Vals {
+			if c.Rows[k] >= c.Cols[k] {
+				entries = append(entries, k)
+			}
+		}
+	} else {
+		for k := range c.Vals {
+			entries = append(entries, k)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix coordinate real %s\n", marketBanner, symmetry)
+	fmt.Fprintf(bw, "%d %d %d\n", c.Size, c.Size, len(entries))
+	for _, k := range entries {
+		fmt.Fprintf(bw, "%d %d %s\n", c.Rows[k]+1, c.Cols[k]+1, strconv.FormatFloat(c.Vals[k], 'g', -1, 64))
+	}
+	return bw.Flush()
+}
+
+// ReadMatrixMarket reads a square real, integer or pattern Matrix Market coordinate file, in which pattern
+// entries have the value 1 and the upper triangular entries of a symmetric matrix are filled in
+func ReadMatrixMarket(r io.Reader) (*COO, error) {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	nextLine := func() (string, bool) {
+		for scanner.Scan() {
+			lineNum++
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "%") {
+				return line, true
+			}
+		}
+		return "", false
+	}
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("missing Matrix Market header")
+	}
+	lineNum++
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != strings.ToLower(marketBanner) || header[1] != "matrix" {
+		return nil, fmt.Errorf("line 1: invalid Matrix Market header")
+	}
+	if header[2] != "coordinate" {
+		return nil, fmt.Errorf("line 1: unsupported format %q", header[2])
+	}
+	field, symmetry := header[3], header[4]
+	if field != "real" && field != "integer" && field != "pattern" {
+		return nil, fmt.Errorf("line 1: unsupported field %q", field)
+	}
+	if symmetry != "general" && symmetry != "symmetric" {
+		return nil, fmt.Errorf("line 1: unsupported symmetry %q", symmetry)
+	}
+
+	line, ok := nextLine()
+	if !ok {
+		return nil, fmt.Errorf("missing Matrix Market size line")
+	}
+	size, err := parseInts(strings.Fields(line), 3)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid size line: %v", lineNum, err)
+	}
+	if size[0] < 0 || size[1] < 0 || size[2] < 0 {
+		return nil, fmt.Errorf("line %d: invalid size line: negative size", lineNum)
+	}
+	if size[0] != size[1] {
+		return nil, fmt.Errorf("line %d: matrix with %d rows and %d columns is not square", lineNum, size[0], size[1])
+	}
+
+	coo := &COO{Size: size[0]}
+	numFields := 3
+	if field == "pattern" {
+		numFields = 2
+	}
+	for entries := 0; entries < size[2]; entries++ {
+		line, ok := nextLine()
+		if !ok {
+			return nil, fmt.Errorf("found %d entries, expected %d", entries, size[2])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != numFields {
+			return nil, fmt.Errorf("line %d: expected %d fields, found %d", lineNum, numFields, len(fields))
+		}
+		idx, err := parseInts(fields[:2], 2)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+		row, col := idx[0]-1, idx[1]-1
+		if row < 0 || row >= coo.Size || col < 0 || col >= coo.Size {
+			return nil, fmt.Errorf("line %d: entry is out of bounds", lineNum)
+		}
+		val := 1.0
+		if field != "pattern" {
+			val, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q", lineNum, fields[2])
+			}
+		}
+
+		coo.Rows = append(coo.Rows, row)
+		coo.Cols = append(coo.Cols, col)
+		coo.Vals = append(coo.Vals, val)
+		if symmetry == "symmetric" && row != col {
+			coo.Rows = append(coo.Rows, col)
+			coo.Cols = append(coo.Cols, row)
+			coo.Vals = append(coo.Vals, val)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return coo, nil
+}
+
+func parseInts(fields []string, count int) ([]int, error) {
+	if len(fields) != count {
+		return nil, fmt.Errorf("expected %d integers, found %d fields", count, len(fields))
+	}
+	ints := make([]int, count)
+	for i, field := range fields {
+		val, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", field)
+		}
+		ints[i] = val
+	}
+	return ints, nil
+}
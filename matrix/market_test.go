@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMatrixMarket(t *testing.T) {
+	t.Run("general", func(t *testing.T) {
+		dg := setupDirGraph()
+		coo, _ := ToCOO(dg, SortedNodeIndex(dg))
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, coo, false)
+		assert.Nil(t, err)
+		expected := "%%MatrixMarket matrix coordinate real general\n4 4 3\n1 3 2\n2 1 1.5\n3 3 3\n"
+		assert.Equal(t, expected, buf.String())
+	})
+	t.Run("symmetric writes the lower triangle", func(t *testing.T) {
+		g := setupGraph()
+		coo, _ := ToCOO(g, SortedNodeIndex(g))
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, coo, true)
+		assert.Nil(t, err)
+		expected := "%%MatrixMarket matrix coordinate real symmetric\n4 4 3\n2 1 1.5\n3 1 2\n3 3 3\n"
+		assert.Equal(t, expected, buf.String())
+	})
+	t.Run("asymmetric matrix written as symmetric should error", func(t *testing.T) {
+		dg := setupDirGraph()
+		coo, _ := ToCOO(dg, SortedNodeIndex(dg))
+		err := WriteMatrixMarket(&bytes.Buffer{}, coo, true)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestReadMatrixMarket(t *testing.T) {
+	t.Run("symmetric with comments", func(t *testing.T) {
+		f := "%%MatrixMarket matrix coordinate real symmetric\n% a comment\n\n3 3 2\n2 1 1.5\n3 3 -2e1\n"
+		coo, err := ReadMatrixMarket(strings.NewReader(f))
+		assert.Nil(t, err)
+		expected := &COO{
+			Size: 3,
+			Rows: []int{1, 0, 2},
+			Cols: []int{0, 1, 2},
+			Vals: []float64{1.5, 1.5, -20},
+		}
+		assert.Equal(t, expected, coo)
+	})
+	t.Run("pattern", func(t *testing.T) {
+		f := "%%MatrixMarket MATRIX Coordinate Pattern General\n2 2 1\n1 2\n"
+		coo, err := ReadMatrixMarket(strings.NewReader(f))
+		assert.Nil(t, err)
+		assert.Equal(t, &COO{Size: 2, Rows: []int{0}, Cols: []int{1}, Vals: []float64{1}}, coo)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		g := setupGraph()
+		idx := SortedNodeIndex(g)
+		coo, _ := ToCOO(g, idx)
+		var buf bytes.Buffer
+		assert.Nil(t, WriteMatrixMarket(&buf, coo, true))
+		rt, err := ReadMatrixMarket(&buf)
+		assert.Nil(t, err)
+		m, _ := coo.ToDense()
+		rtm, err := rt.ToDense()
+		assert.Nil(t, err)
+		assert.Equal(t, m, rtm)
+	})
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":             "",
+		"invalid header":    "%%NotMatrixMarket matrix coordinate real general\n1 1 0\n",
+		"array format":      "%%MatrixMarket matrix array real general\n1 1\n1\n",
+		"complex field":     "%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+		"skew symmetric":    "%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n2 1 1\n",
+		"missing size":      "%%MatrixMarket matrix coordinate real general\n% only comments\n",
+		"not square":        "%%MatrixMarket matrix coordinate real general\n2 3 0\n",
+		"negative size":     "%%MatrixMarket matrix coordinate real general\n-1 -1 0\n",
+		"negative entries":  "%%MatrixMarket matrix coordinate real general\n2 2 -1\n",
+		"too few entries":   "%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"out of bounds":     "%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"zero index":        "%%MatrixMarket matrix coordinate real general\n2 2 1\n0 1 1\n",
+		"invalid value":     "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 x\n",
+		"missing value":     "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1\n",
+		"non integer index": "%%MatrixMarket matrix coordinate real general\n2 2 1\n1.5 1 1\n",
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadMatrixMarket(strings.NewReader(f))
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// NodeIndex is a mapping between nodes and the rows and columns of an adjacency matrix
+type NodeIndex struct {
+	nodes []n.Node
+	index map[n.Node]int
+}
+
+// NewNodeIndex creates a NodeIndex mapping each node to its position in a slice of unique nodes
+func NewNodeIndex(nodes []n.Node) (*NodeIndex, error) {
+	idx := &NodeIndex{
+		nodes: make([]n.Node, len(nodes)),
+		index: make(map[n.Node]int, len(nodes)),
+	}
+	copy(idx.nodes, nodes)
+	for i, node := range nodes {
+		if _, ok := idx.index[node]; ok {
+			return nil, fmt.Errorf("duplicate node %s", node)
+		}
+		idx.index[node] = i
+	}
+	return idx, nil
+}
+
+// SortedNodeIndex creates a NodeIndex mapping the nodes of a graph to their positions in sorted order,
+// which is stable across runs and across graphs with the same nodes
+func SortedNodeIndex(g graph.ReadOnlyGraph) *NodeIndex {
+	nodes := g.GetNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	idx, _ := NewNodeIndex(nodes) // no need to check error since graph nodes are unique
+	return idx
+}
+
+// SequentialNodeIndex creates a NodeIndex for a matrix without node names, mapping
+// the nodes "1", "2", ..., "size" to the rows and columns 0, 1, ..., size-1
+func SequentialNodeIndex(size int) *NodeIndex {
+	nodes := make([]n.Node, size)
+	for i := range nodes {
+		nodes[i] = n.Node(fmt.Sprint(i + 1))
+	}
+	idx, _ := NewNodeIndex(nodes) // no need to check error since nodes are unique
+	return idx
+}
+
+// Len returns the number of nodes in a NodeIndex
+func (idx *NodeIndex) Len() int {
+	return len(idx.nodes)
+}
+
+// Index gets the row and column of a node
+func (idx *NodeIndex) Index(node n.Node) (int, bool) {
+	i, ok := idx.index[node]
+	return i, ok
+}
+
+// Node gets the node of a row or column
+func (idx *NodeIndex) Node(i int) (n.Node, bool) {
+	if i < 0 || i >= len(idx.nodes) {
+		return "", false
+	}
+	return idx.nodes[i], true
+}
+
+// Nodes gets a slice of the nodes ordered by their rows and columns
+func (idx *NodeIndex) Nodes() []n.Node {
+	nodes := make([]n.Node, len(idx.nodes))
+	copy(nodes, idx.nodes)
+	return nodes
+}
+
+// COO is a sparse square matrix in coordinate format, holding the row, column and value of each entry;
+// following the Matrix Market convention, repeated entries for the same row and column are summed
+type COO struct {
+	Size int
+	Rows []int
+	Cols []int
+	Vals []float64
+}
+
+// CSR is a sparse square matrix in compressed sparse row format, in which the entries of row i are
+// found at positions RowPtr[i] through RowPtr[i+1]-1 of Cols and Vals
+type CSR struct {
+	Size   int
+	RowPtr []int
+	Cols   []int
+	Vals   []float64
+}
+
+// ToCOO creates the weighted adjacency matrix of a graph in coordinate format with entries sorted by row
+// and column, which is symmetric for an undirected graph; an error is returned if a node is not indexed
+func ToCOO(g graph.ReadOnlyGraph, idx *NodeIndex) (*COO, error) {
+	coo := &COO{Size: idx.Len()}
+	for _, src := range g.GetNodes() {
+		if _, ok := idx.Index(src); !ok {
+			return nil, fmt.Errorf("node %s is not indexed", src)
+		}
+	}
+	for row, src := range idx.nodes {
+		nbrs, _ := g.GetNeighbors(src)
+		cols := make([]int, 0, len(nbrs))
+		for tgt := range nbrs {
+			col, ok := idx.Index(tgt)
+			if !ok {
+				return nil, fmt.Errorf("node %s is not indexed", tgt)
+			}
+			cols = append(cols, col)
+		}
+		sort.Ints(cols)
+		for _, col := range cols {
+			coo.Rows = append(coo.Rows, row)
+			coo.Cols = append(coo.Cols, col)
+			coo.Vals = append(coo.Vals, nbrs[idx.nodes[col]])
+		}
+	}
+	return coo, nil
+}
+
+// ToCSR creates the weighted adjacency matrix of a graph in compressed sparse row format
+func ToCSR(g graph.ReadOnlyGraph, idx *NodeIndex) (*CSR, error) {
+	coo, err := ToCOO(g, idx)
+	if err != nil {
+		return nil, err
+	}
+	return coo.ToCSR()
+}
+
+// ToDense creates the weighted adjacency matrix of a graph as a dense slice of rows
+func ToDense(g graph.ReadOnlyGraph, idx *NodeIndex) ([][]float64, error) {
+	coo, err := ToCOO(g, idx)
+	if err != nil {
+		return nil, err
+	}
+	return coo.ToDense()
+}
+
+// FromDense creates a matrix in coordinate format from the nonzero entries of a dense square matrix
+func FromDense(m [][]float64) (*COO, error) {
+	coo := &COO{Size: len(m)}
+	for i, row := range m {
+		if len(row) != len(m) {
+			return nil, fmt.Errorf("row %d has %d columns, expected %d", i, len(row), len(m))
+		}
+		for j, val := range row {
+			if val != 0 {
+				coo.Rows = append(coo.Rows, i)
+				coo.Cols = append(coo.Cols, j)
+				coo.Vals = append(coo.Vals, val)
+			}
+		}
+	}
+	return coo, nil
+}
+
+// ToDense creates a dense slice of rows from a matrix in coordinate format, summing repeated entries;
+// an error is returned if an entry is out of bounds
+func (c *COO) ToDense() ([][]float64, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	m := make([][]float64, c.Size)
+	for i := range m {
+		m[i] = make([]float64, c.Size)
+	}
+	for k := range c.Vals {
+		m[c.Rows[k]][c.Cols[k]] += c.Vals[k]
+	}
+	return m, nil
+}
+
+// ToCSR creates a matrix in compressed sparse row format from a matrix in coordinate format,
+// ordering the entries of each row by column and summing repeated entries; an error is returned if an
+// entry is out of bounds
+func (c *COO) ToCSR() (*CSR, error) {
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	order := make([]int, len(c.Vals))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := order[a], order[b]
+		return c.Rows[ka] < c.Rows[kb] || (c.Rows[ka] == c.Rows[kb] && c.Cols[ka] < c.Cols[kb])
+	})
+
+	csr := &CSR{
+		Size:   c.Size,
+		RowPtr: make([]int, c.Size+1),
+		Cols:   make([]int, 0, len(c.Vals)),
+		Vals:   make([]float64, 0, len(c.Vals)),
+	}
+	for i, k := range order {
+		if i > 0 && c.Rows[k] == c.Rows[order[i-1]] && c.Cols[k] == c.Cols[order[i-1]] {
+			csr.Vals[len(csr.Vals)-1] += c.Vals[k]
+			continue
+		}
+		csr.RowPtr[c.Rows[k]+1]++
+		csr.Cols = append(csr.Cols, c.Cols[k])
+		csr.Vals = append(csr.Vals, c.Vals[k])
+	}
+	for i := 0; i < c.Size; i++ {
+		csr.RowPtr[i+1] += csr.RowPtr[i]
+	}
+	return csr, nil
+}
+
+// ToCOO creates a matrix in coordinate format from a matrix in compressed sparse row format
+func (c *CSR) ToCOO() *COO {
+	coo := &COO{
+		Size: c.Size,
+		Rows: make([]int, 0, len(c.Vals)),
+		Cols: append([]int{}, c.Cols...),
+		Vals: append([]float64{}, c.Vals...),
+	}
+	for i := 0; i < c.Size; i++ {
+		for k := c.RowPtr[i]; k < c.RowPtr[i+1]; k++ {
+			coo.Rows = append(coo.Rows, i)
+		}
+	}
+	return coo
+}
+
+// Row gets the columns and values of the entries of a row
+func (c *CSR) Row(i int) ([]int, []float64) {
+	start, end := c.RowPtr[i], c.RowPtr[i+1]
+	return c.Cols[start:end], c.Vals[start:end]
+}
+
+// ToGraph creates an undirected graph with an edge for each entry of a symmetric matrix, mapping rows
+// and columns to nodes using an index; an error is returned if the matrix is not symmetric
+func (c *COO) ToGraph(name string, idx *NodeIndex) (*graph.Graph, error) {
+	if err := c.validate(idx); err != nil {
+		return nil, err
+	}
+	if err := c.checkSymmetric(); err != nil {
+		return nil, err
+	}
+	g, _ := graph.NewGraph(name) // no need to check error since there are no readers
+	for _, node := range idx.nodes {
+		g.AddNode(node)
+	}
+	summed := c.summed()
+	for k, val := range summed.Vals {
+		g.AddEdge(idx.nodes[summed.Rows[k]], idx.nodes[summed.Cols[k]], val)
+	}
+	return g, nil
+}
+
+// ToDirGraph creates a directed graph with an edge for each entry of a matrix, mapping rows
+// and columns to nodes using an index
+func (c *COO) ToDirGraph(name string, idx *NodeIndex) (*graph.DirGraph, error) {
+	if err := c.validate(idx); err != nil {
+		return nil, err
+	}
+	dg, _ := graph.NewDirGraph(name) // no need to check error since there are no readers
+	for _, node := range idx.nodes {
+		dg.AddNode(node)
+	}
+	summed := c.summed()
+	for k, val := range summed.Vals {
+		dg.AddEdge(idx.nodes[summed.Rows[k]], idx.nodes[summed.Cols[k]], val)
+	}
+	return dg, nil
+}
+
+func (c *COO) validate(idx *NodeIndex) error {
+	if idx.Len() != c.Size {
+		return fmt.Errorf("index has %d nodes, expected %d", idx.Len(), c.Size)
+	}
+	return c.check()
+}
+
+// check returns an error if the entries of the matrix are not consistent with its size
+func (c *COO) check() error {
+	if len(c.Rows) != len(c.Vals) || len(c.Cols) != len(c.Vals) {
+		return fmt.Errorf("matrix has %d rows, %d columns and %d values", len(c.Rows), len(c.Cols), len(c.Vals))
+	}
+	for k := range c.Vals {
+		if c.Rows[k] < 0 || c.Rows[k] >= c.Size || c.Cols[k] < 0 || c.Cols[k] >= c.Size {
+			return fmt.Errorf("entry at row %d, column %d is out of bounds", c.Rows[k], c.Cols[k])
+		}
+	}
+	return nil
+}
+
+// summed returns the entries of a matrix that has been checked, with repeated entries summed
+func (c *COO) summed() *COO {
+	csr, _ := c.ToCSR() // no need to check error since the matrix has been checked
+	return csr.ToCOO()
+}
+
+// checkSymmetric returns an error if the sum of the entries at a row and column does not
+// have a mirrored entry with the same value
+func (c *COO) checkSymmetric() error {
+	summed := c.summed()
+	vals := make(map[[2]int]float64, len(summed.Vals))
+	for k, val := range summed.Vals {
+		vals[[2]int{summed.Rows[k], summed.Cols[k]}] = val
+	}
+	for k, val := range summed.Vals {
+		if mirror, ok := vals[[2]int{summed.Cols[k], summed.Rows[k]}]; !ok || mirror != val {
+			return fmt.Errorf("matrix is not symmetric at row %d, column %d", summed.Rows[k], summed.Cols[k])
+		}
+	}
+	return nil
+}
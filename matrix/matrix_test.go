@@ -0,0 +1,253 @@
+package matrix
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupGraph() *graph.Graph {
+	edges := []byte("b a 1.5\na c 2\nc c 3\nd")
+	g, _ := graph.NewGraph("test", ioutil.NopCloser(bytes.NewReader(edges)))
+	return g
+}
+
+func setupDirGraph() *graph.DirGraph {
+	edges := []byte("b a 1.5\na c 2\nc c 3\nd")
+	dg, _ := graph.NewDirGraph("test", ioutil.NopCloser(bytes.NewReader(edges)))
+	return dg
+}
+
+func TestNodeIndex(t *testing.T) {
+	t.Run("sorted index", func(t *testing.T) {
+		idx := SortedNodeIndex(setupDirGraph())
+		assert.Equal(t, 4, idx.Len())
+		assert.Equal(t, []n.Node{"a", "b", "c", "d"}, idx.Nodes())
+		i, ok := idx.Index("c")
+		assert.True(t, ok)
+		assert.Equal(t, 2, i)
+		_, ok = idx.Index("x")
+		assert.False(t, ok)
+		node, ok := idx.Node(1)
+		assert.True(t, ok)
+		assert.Equal(t, n.Node("b"), node)
+		_, ok = idx.Node(4)
+		assert.False(t, ok)
+	})
+	t.Run("index keeps the given order", func(t *testing.T) {
+		idx, err := NewNodeIndex([]n.Node{"z", "a"})
+		assert.Nil(t, err)
+		i, _ := idx.Index("z")
+		assert.Equal(t, 0, i)
+	})
+	t.Run("duplicate nodes should error", func(t *testing.T) {
+		_, err := NewNodeIndex([]n.Node{"a", "b", "a"})
+		assert.NotNil(t, err)
+	})
+	t.Run("sequential index", func(t *testing.T) {
+		idx := SequentialNodeIndex(3)
+		assert.Equal(t, []n.Node{"1", "2", "3"}, idx.Nodes())
+	})
+}
+
+func TestToCOO(t *testing.T) {
+	t.Run("undirected graph is symmetric", func(t *testing.T) {
+		g := setupGraph()
+		coo, err := ToCOO(g, SortedNodeIndex(g))
+		assert.Nil(t, err)
+		expected := &COO{
+			Size: 4,
+			Rows: []int{0, 0, 1, 2, 2},
+			Cols: []int{1, 2, 0, 0, 2},
+			Vals: []float64{1.5, 2, 1.5, 2, 3},
+		}
+		assert.Equal(t, expected, coo)
+	})
+	t.Run("directed graph", func(t *testing.T) {
+		dg := setupDirGraph()
+		coo, err := ToCOO(dg, SortedNodeIndex(dg))
+		assert.Nil(t, err)
+		expected := &COO{
+			Size: 4,
+			Rows: []int{0, 1, 2},
+			Cols: []int{2, 0, 2},
+			Vals: []float64{2, 1.5, 3},
+		}
+		assert.Equal(t, expected, coo)
+	})
+	t.Run("node missing from index should error", func(t *testing.T) {
+		idx, _ := NewNodeIndex([]n.Node{"a", "b", "c"})
+		_, err := ToCOO(setupDirGraph(), idx)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestToDense(t *testing.T) {
+	dg := setupDirGraph()
+	m, err := ToDense(dg, SortedNodeIndex(dg))
+	assert.Nil(t, err)
+	expected := [][]float64{
+		{0, 0, 2, 0},
+		{1.5, 0, 0, 0},
+		{0, 0, 3, 0},
+		{0, 0, 0, 0},
+	}
+	assert.Equal(t, expected, m)
+
+	coo, err := FromDense(m)
+	assert.Nil(t, err)
+	expectedCOO, _ := ToCOO(dg, SortedNodeIndex(dg))
+	assert.Equal(t, expectedCOO, coo)
+
+	_, err = FromDense([][]float64{{1, 2}, {3}})
+	assert.NotNil(t, err)
+}
+
+func TestToCSR(t *testing.T) {
+	g := setupGraph()
+	csr, err := ToCSR(g, SortedNodeIndex(g))
+	assert.Nil(t, err)
+	expected := &CSR{
+		Size:   4,
+		RowPtr: []int{0, 2, 3, 5, 5},
+		Cols:   []int{1, 2, 0, 0, 2},
+		Vals:   []float64{1.5, 2, 1.5, 2, 3},
+	}
+	assert.Equal(t, expected, csr)
+
+	cols, vals := csr.Row(2)
+	assert.Equal(t, []int{0, 2}, cols)
+	assert.Equal(t, []float64{2, 3}, vals)
+	cols, _ = csr.Row(3)
+	assert.Empty(t, cols)
+
+	coo, _ := ToCOO(g, SortedNodeIndex(g))
+	assert.Equal(t, coo, csr.ToCOO())
+}
+
+func TestCOOToCSRUnsorted(t *testing.T) {
+	coo := &COO{Size: 3, Rows: []int{2, 0, 2, 0}, Cols: []int{1, 2, 0, 1}, Vals: []float64{1, 2, 3, 4}}
+	expected := &CSR{
+		Size:   3,
+		RowPtr: []int{0, 2, 2, 4},
+		Cols:   []int{1, 2, 0, 1},
+		Vals:   []float64{4, 2, 3, 1},
+	}
+	csr, err := coo.ToCSR()
+	assert.Nil(t, err)
+	assert.Equal(t, expected, csr)
+}
+
+func TestCOOOutOfBounds(t *testing.T) {
+	tests := map[string]*COO{
+		"row too large":    {Size: 2, Rows: []int{2}, Cols: []int{0}, Vals: []float64{1}},
+		"negative column":  {Size: 2, Rows: []int{0}, Cols: []int{-1}, Vals: []float64{1}},
+		"missing column":   {Size: 2, Rows: []int{0, 1}, Cols: []int{1}, Vals: []float64{1, 1}},
+		"missing value":    {Size: 2, Rows: []int{0}, Cols: []int{1}, Vals: []float64{}},
+		"entries in empty": {Size: 0, Rows: []int{0}, Cols: []int{0}, Vals: []float64{1}},
+	}
+
+	for name, coo := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := coo.ToDense()
+			assert.NotNil(t, err)
+			_, err = coo.ToCSR()
+			assert.NotNil(t, err)
+			err = WriteMatrixMarket(&bytes.Buffer{}, coo, false)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestCOORepeatedEntries(t *testing.T) {
+	// the entry at row 0, column 1 is repeated and is summed by every conversion
+	coo := &COO{Size: 2, Rows: []int{0, 1, 0}, Cols: []int{1, 0, 1}, Vals: []float64{1, 3, 2}}
+	idx := SequentialNodeIndex(2)
+
+	m, err := coo.ToDense()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]float64{{0, 3}, {3, 0}}, m)
+	csr, err := coo.ToCSR()
+	assert.Nil(t, err)
+	assert.Equal(t, &CSR{Size: 2, RowPtr: []int{0, 1, 2}, Cols: []int{1, 0}, Vals: []float64{3, 3}}, csr)
+
+	g, err := coo.ToGraph("test", idx)
+	assert.Nil(t, err)
+	wgt, _ := g.GetEdgeWeight("1", "2")
+	assert.Equal(t, 3.0, wgt)
+
+	dg, err := coo.ToDirGraph("test", idx)
+	assert.Nil(t, err)
+	wgt, _ = dg.GetEdgeWeight("1", "2")
+	assert.Equal(t, 3.0, wgt)
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteMatrixMarket(&buf, coo, true))
+	rt, err := ReadMatrixMarket(&buf)
+	assert.Nil(t, err)
+	rtm, err := rt.ToDense()
+	assert.Nil(t, err)
+	assert.Equal(t, m, rtm)
+
+	// repeated entries that are only symmetric before summing are not symmetric
+	asym := &COO{Size: 2, Rows: []int{0, 1, 0}, Cols: []int{1, 0, 1}, Vals: []float64{1, 1, 1}}
+	_, err = asym.ToGraph("test", idx)
+	assert.NotNil(t, err)
+}
+
+func TestCOOToGraph(t *testing.T) {
+	t.Run("undirected round trip", func(t *testing.T) {
+		g := setupGraph()
+		idx := SortedNodeIndex(g)
+		coo, _ := ToCOO(g, idx)
+		rt, err := coo.ToGraph("test", idx)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, g.GetNodes(), rt.GetNodes())
+		for _, node := range g.GetNodes() {
+			nbrs, _ := g.GetNeighbors(node)
+			rtNbrs, _ := rt.GetNeighbors(node)
+			assert.Equal(t, nbrs, rtNbrs)
+		}
+	})
+	t.Run("directed round trip", func(t *testing.T) {
+		dg := setupDirGraph()
+		idx := SortedNodeIndex(dg)
+		coo, _ := ToCOO(dg, idx)
+		rt, err := coo.ToDirGraph("test", idx)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, dg.GetNodes(), rt.GetNodes())
+		for _, node := range dg.GetNodes() {
+			nbrs, _ := dg.GetNeighbors(node)
+			rtNbrs, _ := rt.GetNeighbors(node)
+			assert.Equal(t, len(nbrs), len(rtNbrs))
+			invNbrs, _ := dg.GetInvNeighbors(node)
+			rtInvNbrs, _ := rt.GetInvNeighbors(node)
+			assert.Equal(t, len(invNbrs), len(rtInvNbrs))
+		}
+	})
+	t.Run("asymmetric matrix to undirected graph should error", func(t *testing.T) {
+		coo := &COO{Size: 2, Rows: []int{0, 1}, Cols: []int{1, 0}, Vals: []float64{1, 2}}
+		_, err := coo.ToGraph("test", SequentialNodeIndex(2))
+		assert.NotNil(t, err)
+	})
+	t.Run("matrix missing a mirrored entry to undirected graph should error", func(t *testing.T) {
+		coo := &COO{Size: 2, Rows: []int{0}, Cols: []int{1}, Vals: []float64{5}}
+		_, err := coo.ToGraph("test", SequentialNodeIndex(2))
+		assert.NotNil(t, err)
+	})
+	t.Run("index of wrong size should error", func(t *testing.T) {
+		coo := &COO{Size: 2}
+		_, err := coo.ToDirGraph("test", SequentialNodeIndex(3))
+		assert.NotNil(t, err)
+	})
+	t.Run("out of bounds entry should error", func(t *testing.T) {
+		coo := &COO{Size: 2, Rows: []int{0}, Cols: []int{2}, Vals: []float64{1}}
+		_, err := coo.ToDirGraph("test", SequentialNodeIndex(2))
+		assert.NotNil(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"container/heap"
 	"errors"
 	"sync"
 )
@@ -8,29 +9,40 @@ import (
 // Node is a node of a graph
 type Node string
 
-type stackItem struct {
-	data Node
-	next *stackItem
+// ErrEmpty is the error returned when popping or peeking from an empty container
+var ErrEmpty = errors.New("container is empty")
+
+type stackItem[T any] struct {
+	data T
+	next *stackItem[T]
 }
 
-// Stack is a LIFO of nodes
-type Stack struct {
+// StackOf is a LIFO of elements of any type
+type StackOf[T any] struct {
 	lock *sync.Mutex
-	last *stackItem
+	last *stackItem[T]
 	len  int
 }
 
+// Stack is a LIFO of nodes
+type Stack = StackOf[Node]
+
 // NewStack returns a pointer to an empty Stack
 func NewStack() *Stack {
-	return &Stack{lock: &sync.Mutex{}}
+	return NewStackOf[Node]()
+}
+
+// NewStackOf returns a pointer to an empty StackOf
+func NewStackOf[T any]() *StackOf[T] {
+	return &StackOf[T]{lock: &sync.Mutex{}}
 }
 
 // Push adds a node to the stack
-func (s *Stack) Push(node Node) {
+func (s *StackOf[T]) Push(node T) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	toPush := &stackItem{data: node}
+	toPush := &stackItem[T]{data: node}
 	if s.last == nil {
 		s.last = toPush
 	} else {
@@ -41,12 +53,13 @@ func (s *Stack) Push(node Node) {
 }
 
 // Pop removes and returns the most recently added node from the stack
-func (s *Stack) Pop() (Node, error) {
+func (s *StackOf[T]) Pop() (T, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	if s.last == nil {
-		return "", errors.New("cannot pop from empty stack")
+		var zero T
+		return zero, ErrEmpty
 	}
 
 	curLast := s.last
@@ -58,38 +71,80 @@ func (s *Stack) Pop() (Node, error) {
 	return val, nil
 }
 
+// Peek returns the most recently added node without removing it from the stack
+func (s *StackOf[T]) Peek() (T, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.last == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return s.last.data, nil
+}
+
 // Len returns the number of nodes in the stack
-func (s *Stack) Len() int {
+func (s *StackOf[T]) Len() int {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	return s.len
 }
 
-type queueItem struct {
-	data Node
-	next *queueItem
+// Clear removes all nodes from the stack
+func (s *StackOf[T]) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.last = nil
+	s.len = 0
+}
+
+// Range calls f for each node in the stack from the most to the least recently added, stopping
+// if f returns false; f must not call methods of the stack
+func (s *StackOf[T]) Range(f func(node T) bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for item := s.last; item != nil; item = item.next {
+		if !f(item.data) {
+			return
+		}
+	}
 }
 
-// Queue is a FIFO of nodes
-type Queue struct {
+type queueItem[T any] struct {
+	data T
+	next *queueItem[T]
+}
+
+// QueueOf is a FIFO of elements of any type
+type QueueOf[T any] struct {
 	lock  *sync.Mutex
-	first *queueItem
-	last  *queueItem
+	first *queueItem[T]
+	last  *queueItem[T]
 	len   int
 }
 
+// Queue is a FIFO of nodes
+type Queue = QueueOf[Node]
+
 // NewQueue creates an empty Queue
 func NewQueue() *Queue {
-	return &Queue{lock: &sync.Mutex{}}
+	return NewQueueOf[Node]()
+}
+
+// NewQueueOf creates an empty QueueOf
+func NewQueueOf[T any]() *QueueOf[T] {
+	return &QueueOf[T]{lock: &sync.Mutex{}}
 }
 
 // Push adds a node to the queue
-func (q *Queue) Push(node Node) {
+func (q *QueueOf[T]) Push(node T) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
-	toPush := &queueItem{data: node}
+	toPush := &queueItem[T]{data: node}
 	if q.last == nil {
 		q.last = toPush
 		q.first = toPush
@@ -101,12 +156,13 @@ func (q *Queue) Push(node Node) {
 }
 
 // Pop removes the first node in the queue
-func (q *Queue) Pop() (Node, error) {
+func (q *QueueOf[T]) Pop() (T, error) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
 	if q.first == nil {
-		return "", errors.New("cannot pop from empty queue")
+		var zero T
+		return zero, ErrEmpty
 	}
 
 	curFirst := q.first
@@ -121,30 +177,82 @@ func (q *Queue) Pop() (Node, error) {
 	return val, nil
 }
 
+// Peek returns the first node in the queue without removing it
+func (q *QueueOf[T]) Peek() (T, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.first == nil {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return q.first.data, nil
+}
+
 // Len returns the number of nodes in the queue
-func (q *Queue) Len() int {
+func (q *QueueOf[T]) Len() int {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
 	return q.len
 }
 
-// Set is an unordered unique collection of nodes
-type Set struct {
+// Clear removes all nodes from the queue
+func (q *QueueOf[T]) Clear() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.first = nil
+	q.last = nil
+	q.len = 0
+}
+
+// Range calls f for each node in the queue from first to last, stopping if f returns
+// false; f must not call methods of the queue
+func (q *QueueOf[T]) Range(f func(node T) bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for item := q.first; item != nil; item = item.next {
+		if !f(item.data) {
+			return
+		}
+	}
+}
+
+// SetOf is an unordered unique collection of elements of any comparable type
+type SetOf[T comparable] struct {
 	lock  *sync.Mutex
-	items map[Node]struct{}
+	items map[T]struct{}
 }
 
+// Set is an unordered unique collection of nodes
+type Set = SetOf[Node]
+
 // NewSet returns a pointer to an empty Set
 func NewSet() *Set {
-	return &Set{
-		items: map[Node]struct{}{},
+	return NewSetOf[Node]()
+}
+
+// NewSetOf returns a pointer to an empty SetOf
+func NewSetOf[T comparable]() *SetOf[T] {
+	return &SetOf[T]{
+		items: map[T]struct{}{},
 		lock:  &sync.Mutex{},
 	}
 }
 
+// NewSetFrom returns a pointer to a SetOf containing specified elements
+func NewSetFrom[T comparable](elems ...T) *SetOf[T] {
+	s := NewSetOf[T]()
+	for _, elem := range elems {
+		s.items[elem] = struct{}{}
+	}
+	return s
+}
+
 // Add adds a node to the set
-func (s *Set) Add(elem Node) {
+func (s *SetOf[T]) Add(elem T) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -152,7 +260,7 @@ func (s *Set) Add(elem Node) {
 }
 
 // Contains returns a bool indicating if the set contains a specified node
-func (s *Set) Contains(elem Node) bool {
+func (s *SetOf[T]) Contains(elem T) bool {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -161,7 +269,7 @@ func (s *Set) Contains(elem Node) bool {
 }
 
 // Len returns the number of nodes in the set
-func (s *Set) Len() int {
+func (s *SetOf[T]) Len() int {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -169,11 +277,11 @@ func (s *Set) Len() int {
 }
 
 // ToSlice returns a slice of all nodes in the set
-func (s *Set) ToSlice() []Node {
+func (s *SetOf[T]) ToSlice() []T {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	sl := make([]Node, len(s.items))
+	sl := make([]T, len(s.items))
 	i := 0
 	for elem := range s.items {
 		sl[i] = elem
@@ -181,3 +289,482 @@ func (s *Set) ToSlice() []Node {
 	}
 	return sl
 }
+
+// Remove removes a node from the set if it is present
+func (s *SetOf[T]) Remove(elem T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.items, elem)
+}
+
+// Clear removes all nodes from the set
+func (s *SetOf[T]) Clear() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.items = map[T]struct{}{}
+}
+
+// Range calls f for each node in the set in no particular order, stopping if f returns
+// false; f must not call methods of the set
+func (s *SetOf[T]) Range(f func(elem T) bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for elem := range s.items {
+		if !f(elem) {
+			return
+		}
+	}
+}
+
+// Union returns a new set of the nodes that are in either set
+func (s *SetOf[T]) Union(other *SetOf[T]) *SetOf[T] {
+	otherItems := other.copyItems()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for elem := range s.items {
+		otherItems[elem] = struct{}{}
+	}
+	return &SetOf[T]{lock: &sync.Mutex{}, items: otherItems}
+}
+
+// Intersection returns a new set of the nodes that are in both sets
+func (s *SetOf[T]) Intersection(other *SetOf[T]) *SetOf[T] {
+	otherItems := other.copyItems()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := NewSetOf[T]()
+	for elem := range s.items {
+		if _, ok := otherItems[elem]; ok {
+			result.items[elem] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set of the nodes that are in the set but not in the other set
+func (s *SetOf[T]) Difference(other *SetOf[T]) *SetOf[T] {
+	otherItems := other.copyItems()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := NewSetOf[T]()
+	for elem := range s.items {
+		if _, ok := otherItems[elem]; !ok {
+			result.items[elem] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset returns a bool indicating if every node in the set is also in the other set
+func (s *SetOf[T]) IsSubset(other *SetOf[T]) bool {
+	otherItems := other.copyItems()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for elem := range s.items {
+		if _, ok := otherItems[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// copyItems copies the items of a set so that operations on two sets never hold both locks,
+// which would deadlock if a set is combined with itself or two sets are combined concurrently
+func (s *SetOf[T]) copyItems() map[T]struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items := make(map[T]struct{}, len(s.items))
+	for elem := range s.items {
+		items[elem] = struct{}{}
+	}
+	return items
+}
+
+type pqItem[T any] struct {
+	data     T
+	priority float64
+}
+
+// pqItems implements heap.Interface as a min-heap ordered by priority
+type pqItems[T any] []*pqItem[T]
+
+func (items pqItems[T]) Len() int           { return len(items) }
+func (items pqItems[T]) Less(i, j int) bool { return items[i].priority < items[j].priority }
+func (items pqItems[T]) Swap(i, j int)      { items[i], items[j] = items[j], items[i] }
+
+func (items *pqItems[T]) Push(x interface{}) {
+	*items = append(*items, x.(*pqItem[T]))
+}
+
+func (items *pqItems[T]) Pop() interface{} {
+	old := *items
+	last := len(old) - 1
+	item := old[last]
+	old[last] = nil // prevent memory leak from the backing array
+	*items = old[:last]
+	return item
+}
+
+// PriorityQueueOf is a collection of elements of any type ordered by minimum priority
+type PriorityQueueOf[T any] struct {
+	lock  *sync.Mutex
+	items *pqItems[T]
+}
+
+// PriorityQueue is a collection of nodes ordered by minimum priority
+type PriorityQueue = PriorityQueueOf[Node]
+
+// NewPriorityQueue returns a pointer to an empty PriorityQueue
+func NewPriorityQueue() *PriorityQueue {
+	return NewPriorityQueueOf[Node]()
+}
+
+// NewPriorityQueueOf returns a pointer to an empty PriorityQueueOf
+func NewPriorityQueueOf[T any]() *PriorityQueueOf[T] {
+	return &PriorityQueueOf[T]{
+		lock:  &sync.Mutex{},
+		items: &pqItems[T]{},
+	}
+}
+
+// Push adds a node with a specified priority to the priority queue
+func (pq *PriorityQueueOf[T]) Push(node T, priority float64) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+
+	heap.Push(pq.items, &pqItem[T]{data: node, priority: priority})
+}
+
+// Pop removes and returns the node with the lowest priority along with its priority
+func (pq *PriorityQueueOf[T]) Pop() (T, float64, error) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+
+	if pq.items.Len() == 0 {
+		var zero T
+		return zero, 0, ErrEmpty
+	}
+
+	item := heap.Pop(pq.items).(*pqItem[T])
+	return item.data, item.priority, nil
+}
+
+// Peek returns the node with the lowest priority along with its priority without removing it
+func (pq *PriorityQueueOf[T]) Peek() (T, float64, error) {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+
+	if pq.items.Len() == 0 {
+		var zero T
+		return zero, 0, ErrEmpty
+	}
+
+	item := (*pq.items)[0]
+	return item.data, item.priority, nil
+}
+
+// Len returns the number of nodes in the priority queue
+func (pq *PriorityQueueOf[T]) Len() int {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+
+	return pq.items.Len()
+}
+
+// Clear removes all nodes from the priority queue
+func (pq *PriorityQueueOf[T]) Clear() {
+	pq.lock.Lock()
+	defer pq.lock.Unlock()
+
+	pq.items = &pqItems[T]{}
+}
+
+// DequeOf is a double-ended queue of elements of any type backed by a ring buffer
+type DequeOf[T any] struct {
+	lock  *sync.Mutex
+	items []T
+	head  int
+	len   int
+}
+
+// Deque is a double-ended queue of nodes
+type Deque = DequeOf[Node]
+
+// NewDeque returns a pointer to an empty Deque
+func NewDeque() *Deque {
+	return NewDequeOf[Node]()
+}
+
+// NewDequeOf returns a pointer to an empty DequeOf
+func NewDequeOf[T any]() *DequeOf[T] {
+	return &DequeOf[T]{lock: &sync.Mutex{}}
+}
+
+// grow doubles the capacity of the ring buffer if it is full, moving the first node to the start
+func (d *DequeOf[T]) grow() {
+	if d.len < len(d.items) {
+		return
+	}
+	size := 2 * len(d.items)
+	if size == 0 {
+		size = 8
+	}
+	items := make([]T, size)
+	for i := 0; i < d.len; i++ {
+		items[i] = d.items[(d.head+i)%len(d.items)]
+	}
+	d.items = items
+	d.head = 0
+}
+
+// index returns the position in the ring buffer of the ith node from the front
+func (d *DequeOf[T]) index(i int) int {
+	return (d.head + i) % len(d.items)
+}
+
+// PushFront adds a node to the front of the deque
+func (d *DequeOf[T]) PushFront(node T) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = node
+	d.len++
+}
+
+// PushBack adds a node to the back of the deque
+func (d *DequeOf[T]) PushBack(node T) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.grow()
+	d.items[d.index(d.len)] = node
+	d.len++
+}
+
+// PopFront removes and returns the node at the front of the deque
+func (d *DequeOf[T]) PopFront() (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var zero T
+	if d.len == 0 {
+		return zero, ErrEmpty
+	}
+	val := d.items[d.head]
+	d.items[d.head] = zero // release the reference held by the ring buffer
+	d.head = d.index(1)
+	d.len--
+	return val, nil
+}
+
+// PopBack removes and returns the node at the back of the deque
+func (d *DequeOf[T]) PopBack() (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var zero T
+	if d.len == 0 {
+		return zero, ErrEmpty
+	}
+	last := d.index(d.len - 1)
+	val := d.items[last]
+	d.items[last] = zero // release the reference held by the ring buffer
+	d.len--
+	return val, nil
+}
+
+// PeekFront returns the node at the front of the deque without removing it
+func (d *DequeOf[T]) PeekFront() (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.len == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return d.items[d.head], nil
+}
+
+// PeekBack returns the node at the back of the deque without removing it
+func (d *DequeOf[T]) PeekBack() (T, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.len == 0 {
+		var zero T
+		return zero, ErrEmpty
+	}
+	return d.items[d.index(d.len-1)], nil
+}
+
+// Len returns the number of nodes in the deque
+func (d *DequeOf[T]) Len() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.len
+}
+
+// Clear removes all nodes from the deque
+func (d *DequeOf[T]) Clear() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.items = nil
+	d.head = 0
+	d.len = 0
+}
+
+// Range calls f for each node in the deque from front to back, stopping if f returns
+// false; f must not call methods of the deque
+func (d *DequeOf[T]) Range(f func(node T) bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for i := 0; i < d.len; i++ {
+		if !f(d.items[d.index(i)]) {
+			return
+		}
+	}
+}
+
+// DisjointSetOf is a collection of disjoint sets of elements of any comparable type
+// supporting union and find operations
+type DisjointSetOf[T comparable] struct {
+	lock   *sync.Mutex
+	parent map[T]T
+	rank   map[T]int
+	count  int
+}
+
+// DisjointSet is a collection of disjoint sets of nodes supporting union and find operations
+type DisjointSet = DisjointSetOf[Node]
+
+// NewDisjointSet returns a pointer to an empty DisjointSet
+func NewDisjointSet() *DisjointSet {
+	return NewDisjointSetOf[Node]()
+}
+
+// NewDisjointSetOf returns a pointer to an empty DisjointSetOf
+func NewDisjointSetOf[T comparable]() *DisjointSetOf[T] {
+	return &DisjointSetOf[T]{
+		lock:   &sync.Mutex{},
+		parent: map[T]T{},
+		rank:   map[T]int{},
+	}
+}
+
+// Add adds a node to the disjoint set as a singleton set if it is not already present
+func (d *DisjointSetOf[T]) Add(node T) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.add(node)
+}
+
+func (d *DisjointSetOf[T]) add(node T) {
+	if _, ok := d.parent[node]; ok {
+		return
+	}
+	d.parent[node] = node
+	d.rank[node] = 0
+	d.count++
+}
+
+// Find returns the representative node of the set containing a node, returning false if the node is not present
+func (d *DisjointSetOf[T]) Find(node T) (T, bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, ok := d.parent[node]; !ok {
+		var zero T
+		return zero, false
+	}
+	return d.find(node), true
+}
+
+func (d *DisjointSetOf[T]) find(node T) T {
+	root := node
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// compress the path so that subsequent finds are faster
+	for node != root {
+		next := d.parent[node]
+		d.parent[node] = root
+		node = next
+	}
+	return root
+}
+
+// Union merges the sets containing two nodes, adding either node if it is not already present,
+// and returns false if the nodes were already in the same set
+func (d *DisjointSetOf[T]) Union(a T, b T) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.add(a)
+	d.add(b)
+
+	rootA := d.find(a)
+	rootB := d.find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	// attach the shallower tree under the deeper tree to keep trees balanced
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	d.count--
+	return true
+}
+
+// Connected returns a bool indicating if two nodes are present and in the same set
+func (d *DisjointSetOf[T]) Connected(a T, b T) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	_, okA := d.parent[a]
+	_, okB := d.parent[b]
+	if !okA || !okB {
+		return false
+	}
+	return d.find(a) == d.find(b)
+}
+
+// Len returns the number of nodes in the disjoint set
+func (d *DisjointSetOf[T]) Len() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return len(d.parent)
+}
+
+// Count returns the number of disjoint sets
+func (d *DisjointSetOf[T]) Count() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.count
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
@@ -8,9 +9,9 @@ import (
 )
 
 func setupStack() *Stack {
-	itemX := &stackItem{data: "x"}
-	itemY := &stackItem{data: "y", next: itemX}
-	itemZ := &stackItem{data: "z", next: itemY}
+	itemX := &stackItem[Node]{data: "x"}
+	itemY := &stackItem[Node]{data: "y", next: itemX}
+	itemZ := &stackItem[Node]{data: "z", next: itemY}
 	return &Stack{
 		lock: &sync.Mutex{},
 		last: itemZ,
@@ -75,7 +76,7 @@ func TestStackPop(t *testing.T) {
 			curStackLen := test.stack.len
 			n, err := test.stack.Pop()
 			if test.shouldErr {
-				assert.NotNil(t, err)
+				assert.True(t, errors.Is(err, ErrEmpty))
 				return
 			}
 			assert.Nil(t, err)
@@ -134,9 +135,9 @@ func TestStackLen(t *testing.T) {
 }
 
 func setupQueue() *Queue {
-	itemZ := &queueItem{data: "z"}
-	itemY := &queueItem{data: "y", next: itemZ}
-	itemX := &queueItem{data: "x", next: itemY}
+	itemZ := &queueItem[Node]{data: "z"}
+	itemY := &queueItem[Node]{data: "y", next: itemZ}
+	itemX := &queueItem[Node]{data: "x", next: itemY}
 	return &Queue{
 		lock:  &sync.Mutex{},
 		first: itemX,
@@ -203,7 +204,7 @@ func TestQueuePop(t *testing.T) {
 			curQueueLen := test.queue.len
 			n, err := test.queue.Pop()
 			if test.shouldErr {
-				assert.NotNil(t, err)
+				assert.True(t, errors.Is(err, ErrEmpty))
 				return
 			}
 			assert.Nil(t, err)
@@ -373,3 +374,592 @@ func TestSetToSlice(t *testing.T) {
 		})
 	}
 }
+
+func setupPriorityQueue() *PriorityQueue {
+	pq := NewPriorityQueue()
+	pq.Push("y", 2.5)
+	pq.Push("x", 1.5)
+	pq.Push("z", 7)
+	return pq
+}
+
+func TestNewPriorityQueue(t *testing.T) {
+	t.Run("new PriorityQueue is empty", func(t *testing.T) {
+		pq := NewPriorityQueue()
+		assert.Zero(t, pq.items.Len())
+	})
+}
+
+func TestPriorityQueuePush(t *testing.T) {
+	tests := map[string]struct {
+		pq       *PriorityQueue
+		toPush   Node
+		priority float64
+	}{
+		"push to empty priority queue": {
+			pq:       NewPriorityQueue(),
+			toPush:   "a",
+			priority: 1,
+		},
+		"push to nonempty priority queue": {
+			pq:       setupPriorityQueue(),
+			toPush:   "a",
+			priority: 3,
+		},
+		"push to priority queue already containing same element": {
+			pq:       setupPriorityQueue(),
+			toPush:   "x",
+			priority: 0.5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			curLen := test.pq.items.Len()
+			test.pq.Push(test.toPush, test.priority)
+			assert.Equal(t, curLen+1, test.pq.items.Len())
+		})
+	}
+}
+
+func TestPriorityQueuePop(t *testing.T) {
+	tests := map[string]struct {
+		pq               *PriorityQueue
+		shouldErr        bool
+		expectedNode     Node
+		expectedPriority float64
+	}{
+		"pop from empty priority queue should error": {
+			pq:        NewPriorityQueue(),
+			shouldErr: true,
+		},
+		"pop from nonempty priority queue": {
+			pq:               setupPriorityQueue(),
+			shouldErr:        false,
+			expectedNode:     "x",
+			expectedPriority: 1.5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			curLen := test.pq.items.Len()
+			n, p, err := test.pq.Pop()
+			if test.shouldErr {
+				assert.True(t, errors.Is(err, ErrEmpty))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedNode, n)
+			assert.Equal(t, test.expectedPriority, p)
+			assert.Equal(t, curLen-1, test.pq.items.Len())
+		})
+	}
+}
+
+func TestPriorityQueuePopOrder(t *testing.T) {
+	t.Run("priority queue pops in order of increasing priority", func(t *testing.T) {
+		pq := setupPriorityQueue()
+		pq.Push("a", 0)
+		pq.Push("b", 5)
+		expected := []Node{"a", "x", "y", "b", "z"}
+		for _, e := range expected {
+			n, _, err := pq.Pop()
+			assert.Nil(t, err)
+			assert.Equal(t, e, n)
+		}
+		assert.Zero(t, pq.Len())
+	})
+}
+
+func TestPriorityQueueLen(t *testing.T) {
+	tests := map[string]struct {
+		pq    *PriorityQueue
+		pqLen int
+	}{
+		"empty priority queue": {
+			pq:    NewPriorityQueue(),
+			pqLen: 0,
+		},
+		"nonempty priority queue": {
+			pq:    setupPriorityQueue(),
+			pqLen: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.pqLen, test.pq.Len())
+		})
+	}
+}
+
+func setupDisjointSet() *DisjointSet {
+	d := NewDisjointSet()
+	d.Union("x", "y")
+	d.Union("y", "z")
+	d.Add("w")
+	return d
+}
+
+func TestNewDisjointSet(t *testing.T) {
+	t.Run("new DisjointSet is empty", func(t *testing.T) {
+		d := NewDisjointSet()
+		assert.Empty(t, d.parent)
+		assert.Zero(t, d.count)
+	})
+}
+
+func TestDisjointSetAdd(t *testing.T) {
+	tests := map[string]struct {
+		d             *DisjointSet
+		toAdd         Node
+		expectedCount int
+	}{
+		"add to empty disjoint set": {
+			d:             NewDisjointSet(),
+			toAdd:         "a",
+			expectedCount: 1,
+		},
+		"add to nonempty disjoint set": {
+			d:             setupDisjointSet(),
+			toAdd:         "a",
+			expectedCount: 3,
+		},
+		"add node already present": {
+			d:             setupDisjointSet(),
+			toAdd:         "x",
+			expectedCount: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.d.Add(test.toAdd)
+			assert.Contains(t, test.d.parent, test.toAdd)
+			assert.Equal(t, test.expectedCount, test.d.Count())
+		})
+	}
+}
+
+func TestDisjointSetFind(t *testing.T) {
+	tests := map[string]struct {
+		node        Node
+		sameSetAs   []Node
+		shouldExist bool
+	}{
+		"node not present": {
+			node:        "a",
+			shouldExist: false,
+		},
+		"singleton node": {
+			node:        "w",
+			sameSetAs:   []Node{"w"},
+			shouldExist: true,
+		},
+		"node in merged set": {
+			node:        "z",
+			sameSetAs:   []Node{"x", "y"},
+			shouldExist: true,
+		},
+	}
+
+	d := setupDisjointSet()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			root, ok := d.Find(test.node)
+			assert.Equal(t, test.shouldExist, ok)
+			for _, other := range test.sameSetAs {
+				otherRoot, _ := d.Find(other)
+				assert.Equal(t, root, otherRoot)
+			}
+		})
+	}
+}
+
+func TestDisjointSetUnion(t *testing.T) {
+	tests := map[string]struct {
+		a             Node
+		b             Node
+		shouldMerge   bool
+		expectedCount int
+		expectedLen   int
+	}{
+		"union of nodes already in same set": {
+			a:             "x",
+			b:             "z",
+			shouldMerge:   false,
+			expectedCount: 2,
+			expectedLen:   4,
+		},
+		"union of nodes in different sets": {
+			a:             "x",
+			b:             "w",
+			shouldMerge:   true,
+			expectedCount: 1,
+			expectedLen:   4,
+		},
+		"union with node not present": {
+			a:             "w",
+			b:             "a",
+			shouldMerge:   true,
+			expectedCount: 2,
+			expectedLen:   5,
+		},
+		"union of node with itself": {
+			a:             "a",
+			b:             "a",
+			shouldMerge:   false,
+			expectedCount: 3,
+			expectedLen:   5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := setupDisjointSet()
+			merged := d.Union(test.a, test.b)
+			assert.Equal(t, test.shouldMerge, merged)
+			assert.True(t, d.Connected(test.a, test.b))
+			assert.Equal(t, test.expectedCount, d.Count())
+			assert.Equal(t, test.expectedLen, d.Len())
+		})
+	}
+}
+
+func TestDisjointSetConnected(t *testing.T) {
+	tests := map[string]struct {
+		a         Node
+		b         Node
+		connected bool
+	}{
+		"nodes in same set": {
+			a:         "x",
+			b:         "z",
+			connected: true,
+		},
+		"nodes in different sets": {
+			a:         "x",
+			b:         "w",
+			connected: false,
+		},
+		"node not present": {
+			a:         "x",
+			b:         "a",
+			connected: false,
+		},
+		"node not present with itself": {
+			a:         "a",
+			b:         "a",
+			connected: false,
+		},
+	}
+
+	d := setupDisjointSet()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.connected, d.Connected(test.a, test.b))
+		})
+	}
+}
+
+func TestDisjointSetLen(t *testing.T) {
+	tests := map[string]struct {
+		d     *DisjointSet
+		dLen  int
+		count int
+	}{
+		"empty disjoint set": {
+			d: NewDisjointSet(),
+		},
+		"nonempty disjoint set": {
+			d:     setupDisjointSet(),
+			dLen:  4,
+			count: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.dLen, test.d.Len())
+			assert.Equal(t, test.count, test.d.Count())
+		})
+	}
+}
+
+func TestGenericContainers(t *testing.T) {
+	t.Run("stack of ints", func(t *testing.T) {
+		s := NewStackOf[int]()
+		s.Push(1)
+		s.Push(2)
+		val, err := s.Pop()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, val)
+		_, _ = s.Pop()
+		val, err = s.Pop()
+		assert.True(t, errors.Is(err, ErrEmpty))
+		assert.Zero(t, val)
+	})
+	t.Run("queue of ints", func(t *testing.T) {
+		q := NewQueueOf[int]()
+		q.Push(1)
+		q.Push(2)
+		val, err := q.Pop()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, val)
+		assert.Equal(t, 1, q.Len())
+	})
+	t.Run("set of structs", func(t *testing.T) {
+		type key struct{ a, b int }
+		s := NewSetOf[key]()
+		s.Add(key{1, 2})
+		s.Add(key{1, 2})
+		assert.True(t, s.Contains(key{1, 2}))
+		assert.False(t, s.Contains(key{2, 1}))
+		assert.Equal(t, []key{{1, 2}}, s.ToSlice())
+	})
+	t.Run("priority queue of ints", func(t *testing.T) {
+		pq := NewPriorityQueueOf[int64]()
+		pq.Push(7, 2)
+		pq.Push(9, 1)
+		val, priority, err := pq.Pop()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(9), val)
+		assert.Equal(t, 1.0, priority)
+	})
+	t.Run("disjoint set of ints", func(t *testing.T) {
+		d := NewDisjointSetOf[int]()
+		d.Union(1, 2)
+		d.Add(3)
+		assert.True(t, d.Connected(1, 2))
+		assert.False(t, d.Connected(1, 3))
+		assert.Equal(t, 2, d.Count())
+		_, ok := d.Find(4)
+		assert.False(t, ok)
+	})
+}
+
+func TestStackPeekClearRange(t *testing.T) {
+	s := setupStack()
+	top, err := s.Peek()
+	assert.Nil(t, err)
+	assert.Equal(t, Node("z"), top)
+	assert.Equal(t, 3, s.Len())
+
+	visited := []Node{}
+	s.Range(func(node Node) bool {
+		visited = append(visited, node)
+		return node != "y"
+	})
+	assert.Equal(t, []Node{"z", "y"}, visited)
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+	_, err = s.Peek()
+	assert.True(t, errors.Is(err, ErrEmpty))
+	s.Push("a")
+	top, _ = s.Peek()
+	assert.Equal(t, Node("a"), top)
+}
+
+func TestQueuePeekClearRange(t *testing.T) {
+	q := setupQueue()
+	first, err := q.Peek()
+	assert.Nil(t, err)
+	assert.Equal(t, Node("x"), first)
+	assert.Equal(t, 3, q.Len())
+
+	visited := []Node{}
+	q.Range(func(node Node) bool {
+		visited = append(visited, node)
+		return true
+	})
+	assert.Equal(t, []Node{"x", "y", "z"}, visited)
+
+	q.Clear()
+	assert.Zero(t, q.Len())
+	_, err = q.Peek()
+	assert.True(t, errors.Is(err, ErrEmpty))
+	q.Push("a")
+	q.Push("b")
+	first, _ = q.Pop()
+	assert.Equal(t, Node("a"), first)
+}
+
+func TestSetRemoveClearRange(t *testing.T) {
+	s := NewSetFrom[Node]("a", "b", "c")
+	s.Remove("b")
+	s.Remove("x")
+	assert.ElementsMatch(t, []Node{"a", "c"}, s.ToSlice())
+
+	count := 0
+	s.Range(func(Node) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+
+	s.Clear()
+	assert.Zero(t, s.Len())
+	s.Add("d")
+	assert.True(t, s.Contains("d"))
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSetFrom(1, 2, 3)
+	b := NewSetFrom(2, 3, 4)
+	empty := NewSetOf[int]()
+
+	tests := map[string]struct {
+		result   *SetOf[int]
+		expected []int
+	}{
+		"union":                  {result: a.Union(b), expected: []int{1, 2, 3, 4}},
+		"union with empty set":   {result: a.Union(empty), expected: []int{1, 2, 3}},
+		"union with itself":      {result: a.Union(a), expected: []int{1, 2, 3}},
+		"intersection":           {result: a.Intersection(b), expected: []int{2, 3}},
+		"intersection with self": {result: a.Intersection(a), expected: []int{1, 2, 3}},
+		"intersection with empty set": {
+			result:   a.Intersection(empty),
+			expected: []int{},
+		},
+		"difference":           {result: a.Difference(b), expected: []int{1}},
+		"reverse difference":   {result: b.Difference(a), expected: []int{4}},
+		"difference with self": {result: a.Difference(a), expected: []int{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ElementsMatch(t, test.expected, test.result.ToSlice())
+		})
+	}
+
+	t.Run("operands are unchanged", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{1, 2, 3}, a.ToSlice())
+		assert.ElementsMatch(t, []int{2, 3, 4}, b.ToSlice())
+	})
+	t.Run("subset", func(t *testing.T) {
+		assert.True(t, NewSetFrom(2, 3).IsSubset(a))
+		assert.True(t, a.IsSubset(a))
+		assert.True(t, empty.IsSubset(a))
+		assert.False(t, a.IsSubset(b))
+		assert.False(t, a.IsSubset(empty))
+	})
+	t.Run("concurrent operations in both directions", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				a.Union(b)
+			}()
+			go func() {
+				defer wg.Done()
+				b.Intersection(a)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestPriorityQueuePeekClear(t *testing.T) {
+	pq := NewPriorityQueue()
+	_, _, err := pq.Peek()
+	assert.True(t, errors.Is(err, ErrEmpty))
+
+	pq.Push("a", 2)
+	pq.Push("b", 1)
+	node, priority, err := pq.Peek()
+	assert.Nil(t, err)
+	assert.Equal(t, Node("b"), node)
+	assert.Equal(t, 1.0, priority)
+	assert.Equal(t, 2, pq.Len())
+
+	pq.Clear()
+	assert.Zero(t, pq.Len())
+	pq.Push("c", 3)
+	node, _, _ = pq.Pop()
+	assert.Equal(t, Node("c"), node)
+}
+
+func TestDeque(t *testing.T) {
+	t.Run("empty deque", func(t *testing.T) {
+		d := NewDeque()
+		assert.Zero(t, d.Len())
+		for _, f := range []func() (Node, error){d.PopFront, d.PopBack, d.PeekFront, d.PeekBack} {
+			val, err := f()
+			assert.True(t, errors.Is(err, ErrEmpty))
+			assert.Equal(t, Node(""), val)
+		}
+	})
+	t.Run("push and pop at both ends", func(t *testing.T) {
+		d := NewDequeOf[int]()
+		d.PushBack(2)
+		d.PushFront(1)
+		d.PushBack(3)
+		front, _ := d.PeekFront()
+		back, _ := d.PeekBack()
+		assert.Equal(t, 1, front)
+		assert.Equal(t, 3, back)
+
+		val, err := d.PopBack()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, val)
+		val, err = d.PopFront()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, val)
+		val, _ = d.PopFront()
+		assert.Equal(t, 2, val)
+		assert.Zero(t, d.Len())
+	})
+	t.Run("grows while wrapped around", func(t *testing.T) {
+		d := NewDequeOf[int]()
+		expected := []int{}
+		// alternate ends so that the ring buffer wraps around before each resize
+		for i := 0; i < 50; i++ {
+			if i%2 == 0 {
+				d.PushFront(i)
+				expected = append([]int{i}, expected...)
+			} else {
+				d.PushBack(i)
+				expected = append(expected, i)
+			}
+		}
+		assert.Equal(t, 50, d.Len())
+
+		visited := []int{}
+		d.Range(func(val int) bool {
+			visited = append(visited, val)
+			return true
+		})
+		assert.Equal(t, expected, visited)
+
+		for _, e := range expected {
+			val, err := d.PopFront()
+			assert.Nil(t, err)
+			assert.Equal(t, e, val)
+		}
+	})
+	t.Run("range stops early", func(t *testing.T) {
+		d := NewDequeOf[int]()
+		for i := 0; i < 5; i++ {
+			d.PushBack(i)
+		}
+		visited := []int{}
+		d.Range(func(val int) bool {
+			visited = append(visited, val)
+			return val < 2
+		})
+		assert.Equal(t, []int{0, 1, 2}, visited)
+	})
+	t.Run("clear", func(t *testing.T) {
+		d := NewDeque()
+		d.PushBack("a")
+		d.PushFront("b")
+		d.Clear()
+		assert.Zero(t, d.Len())
+		d.PushBack("c")
+		val, _ := d.PopFront()
+		assert.Equal(t, Node("c"), val)
+	})
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+// Dijkstra computes the weighted shortest paths from a specified node to all nodes reachable
+// from it, returning the distance to each node and each node's predecessor on its shortest path
+func Dijkstra[T comparable](g graph.ReadOnlyGraphOf[T], node T) (map[T]float64, map[T]T, error) {
+	dist := map[T]float64{}
+	prev := map[T]T{}
+
+	if !g.HasNode(node) {
+		return dist, prev, nil
+	}
+
+	visited := n.NewSetOf[T]()
+
+	pq := n.NewPriorityQueueOf[T]()
+	pq.Push(node, 0)
+	dist[node] = 0
+
+	for pq.Len() > 0 {
+		curNode, curDist, _ := pq.Pop() // no need to check error since the queue cannot be empty here
+		// nodes can be pushed more than once so skip those with an already finalized distance
+		if visited.Contains(curNode) {
+			continue
+		}
+		visited.Add(curNode)
+
+		var err error
+		graph.RangeNeighbors(g, curNode, func(nbr T, wgt float64) bool {
+			if wgt < 0 {
+				err = fmt.Errorf("negative weight %f on edge from %v to %v", wgt, curNode, nbr)
+				return false
+			}
+			if visited.Contains(nbr) {
+				return true
+			}
+			nbrDist := curDist + wgt
+			if d, ok := dist[nbr]; ok && d <= nbrDist {
+				return true
+			}
+			dist[nbr] = nbrDist
+			prev[nbr] = curNode
+			pq.Push(nbr, nbrDist)
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return dist, prev, nil
+}
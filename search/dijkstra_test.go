@@ -0,0 +1,157 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+func setupWeightedGraph() *graph.Graph {
+	g, _ := graph.NewGraph("weighted")
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("c", "d", 5)
+	g.AddEdge("d", "d", 0.5)
+	g.AddEdge("x", "y", 1)
+	return g
+}
+
+func setupWeightedDirGraph() *graph.DirGraph {
+	g, _ := graph.NewDirGraph("weighted")
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("c", "b", 2)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("d", "a", 1.5)
+	g.AddEdge("c", "d", 5)
+	g.AddEdge("x", "y", 1)
+	return g
+}
+
+func TestDijkstra_UndirectedGraph(t *testing.T) {
+	tests := map[string]struct {
+		g            *graph.Graph
+		start        n.Node
+		expectedDist map[n.Node]float64
+		expectedPrev map[n.Node]n.Node
+	}{
+		"empty graph, undirected graph": {
+			g:            setupEmptyGraph(),
+			start:        "a",
+			expectedDist: map[n.Node]float64{},
+			expectedPrev: map[n.Node]n.Node{},
+		},
+		"starting from non-existent node, undirected graph": {
+			g:            setupWeightedGraph(),
+			start:        "z",
+			expectedDist: map[n.Node]float64{},
+			expectedPrev: map[n.Node]n.Node{},
+		},
+		"starting from node in large component, undirected graph": {
+			g:            setupWeightedGraph(),
+			start:        "a",
+			expectedDist: map[n.Node]float64{"a": 0, "b": 3, "c": 1, "d": 4},
+			expectedPrev: map[n.Node]n.Node{"b": "c", "c": "a", "d": "b"},
+		},
+		"starting from node with self loop, undirected graph": {
+			g:            setupWeightedGraph(),
+			start:        "d",
+			expectedDist: map[n.Node]float64{"a": 4, "b": 1, "c": 3, "d": 0},
+			expectedPrev: map[n.Node]n.Node{"a": "c", "b": "d", "c": "b"},
+		},
+		"starting from node in small component, undirected graph": {
+			g:            setupWeightedGraph(),
+			start:        "y",
+			expectedDist: map[n.Node]float64{"x": 1, "y": 0},
+			expectedPrev: map[n.Node]n.Node{"x": "y"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dist, prev, err := Dijkstra(test.g, test.start)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedDist, dist)
+			assert.Equal(t, test.expectedPrev, prev)
+		})
+	}
+}
+
+func TestDijkstra_DirectedGraph(t *testing.T) {
+	tests := map[string]struct {
+		g            *graph.DirGraph
+		start        n.Node
+		expectedDist map[n.Node]float64
+		expectedPrev map[n.Node]n.Node
+	}{
+		"empty graph, directed graph": {
+			g:            setupEmptyDirGraph(),
+			start:        "a",
+			expectedDist: map[n.Node]float64{},
+			expectedPrev: map[n.Node]n.Node{},
+		},
+		"starting from non-existent node, directed graph": {
+			g:            setupWeightedDirGraph(),
+			start:        "z",
+			expectedDist: map[n.Node]float64{},
+			expectedPrev: map[n.Node]n.Node{},
+		},
+		"starting from node in cycle, directed graph": {
+			g:            setupWeightedDirGraph(),
+			start:        "b",
+			expectedDist: map[n.Node]float64{"a": 2.5, "b": 0, "c": 3.5, "d": 1},
+			expectedPrev: map[n.Node]n.Node{"a": "d", "c": "a", "d": "b"},
+		},
+		"starting from terminal node, directed graph": {
+			g:            setupWeightedDirGraph(),
+			start:        "y",
+			expectedDist: map[n.Node]float64{"y": 0},
+			expectedPrev: map[n.Node]n.Node{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dist, prev, err := Dijkstra(test.g, test.start)
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedDist, dist)
+			assert.Equal(t, test.expectedPrev, prev)
+		})
+	}
+}
+
+func TestDijkstraNegativeWeight(t *testing.T) {
+	t.Run("negative edge weight should error", func(t *testing.T) {
+		g := setupWeightedDirGraph()
+		g.AddEdge("b", "e", -1)
+		dist, prev, err := Dijkstra(g, "a")
+		assert.NotNil(t, err)
+		assert.Nil(t, dist)
+		assert.Nil(t, prev)
+	})
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	benchmarkSearch(b, func(g graph.ReadOnlyGraph, node n.Node) { _, _, _ = Dijkstra(g, node) })
+}
+
+func TestDijkstraGenericNodes(t *testing.T) {
+	type cell struct{ row, col int }
+	g := graph.NewGraphOf[cell]("grid")
+	g.AddEdge(cell{0, 0}, cell{0, 1}, 1)
+	g.AddEdge(cell{0, 1}, cell{1, 1}, 1)
+	g.AddEdge(cell{0, 0}, cell{1, 0}, 1)
+	g.AddEdge(cell{1, 0}, cell{1, 1}, 3)
+
+	dist, prev, err := Dijkstra(g, cell{0, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, dist[cell{1, 1}])
+	path, ok := Path(prev, cell{0, 0}, cell{1, 1})
+	assert.True(t, ok)
+	assert.Equal(t, []cell{{0, 0}, {0, 1}, {1, 1}}, path)
+}
@@ -1,24 +1,53 @@
 package search
 
 import (
+	"github.com/dkaslovsky/GoGraph/graph"
 	n "github.com/dkaslovsky/GoGraph/node"
 )
 
-type hasNodeNeighborGetter interface {
-	HasNode(n.Node) bool
-	GetNeighbors(n.Node) (map[n.Node]float64, bool)
+// TraversalOf is the result of a search from a start node, holding the order in which nodes
+// were visited along with the depth and search tree parent of each visited node
+type TraversalOf[T comparable] struct {
+	Order  []T
+	Depth  map[T]int
+	Parent map[T]T
 }
 
-// DFS performs a depth first search starting at a specified node
-func DFS(g hasNodeNeighborGetter, node n.Node) []n.Node {
+// Traversal is a TraversalOf with string nodes
+type Traversal = TraversalOf[n.Node]
 
+func newTraversal[T comparable]() *TraversalOf[T] {
+	return &TraversalOf[T]{
+		Order:  []T{},
+		Depth:  map[T]int{},
+		Parent: map[T]T{},
+	}
+}
+
+// PathTo returns the path along the search tree from the start node to a target node,
+// returning false if the target was not visited
+func (t *TraversalOf[T]) PathTo(tgt T) ([]T, bool) {
+	if len(t.Order) == 0 {
+		return []T{}, false
+	}
+	return Path(t.Parent, t.Order[0], tgt)
+}
+
+// DFS performs a depth first search starting at a specified node and returns the visited nodes in order
+func DFS[T comparable](g graph.ReadOnlyGraphOf[T], node T) []T {
+	return DFSTraversal(g, node).Order
+}
+
+// DFSTraversal performs a depth first search starting at a specified node
+func DFSTraversal[T comparable](g graph.ReadOnlyGraphOf[T], node T) *TraversalOf[T] {
+	t := newTraversal[T]()
 	if !g.HasNode(node) {
-		return []n.Node{}
+		return t
 	}
 
-	visited := n.NewSet()
+	visited := n.NewSetOf[T]()
 
-	s := n.NewStack()
+	s := n.NewStackOf[T]()
 	s.Push(node)
 
 	for s.Len() > 0 {
@@ -28,44 +57,88 @@ func DFS(g hasNodeNeighborGetter, node n.Node) []n.Node {
 		}
 		visited.Add(curNode)
 
-		nbrs, ok := g.GetNeighbors(curNode)
-		if !ok {
-			continue
+		t.Order = append(t.Order, curNode)
+		if parent, ok := t.Parent[curNode]; ok {
+			t.Depth[curNode] = t.Depth[parent] + 1
+		} else {
+			t.Depth[curNode] = 0
 		}
-		for nbr := range nbrs {
+
+		graph.RangeNeighbors(g, curNode, func(nbr T, _ float64) bool {
+			if visited.Contains(nbr) {
+				return true
+			}
+			// the most recent push of a node is the first to be popped
+			// so its parent is always the node that last pushed it
+			t.Parent[nbr] = curNode
 			s.Push(nbr)
-		}
+			return true
+		})
 	}
 
-	return visited.ToSlice()
+	return t
 }
 
-// BFS performs a breadth first search starting at a specified node
-func BFS(g hasNodeNeighborGetter, node n.Node) []n.Node {
+// BFS performs a breadth first search starting at a specified node and returns the visited nodes in order
+func BFS[T comparable](g graph.ReadOnlyGraphOf[T], node T) []T {
+	return BFSTraversal(g, node).Order
+}
+
+// BFSTraversal performs a breadth first search starting at a specified node
+func BFSTraversal[T comparable](g graph.ReadOnlyGraphOf[T], node T) *TraversalOf[T] {
+	t := newTraversal[T]()
 	if !g.HasNode(node) {
-		return []n.Node{}
+		return t
 	}
 
-	visited := n.NewSet()
+	// nodes are marked as discovered when pushed so that each node is
+	// pushed only once and its parent is the first node to reach it
+	discovered := n.NewSetOf[T]()
+	discovered.Add(node)
+	t.Depth[node] = 0
 
-	q := n.NewQueue()
+	q := n.NewQueueOf[T]()
 	q.Push(node)
 
 	for q.Len() > 0 {
 		curNode, _ := q.Pop() // no need to check error since the queue cannot be empty here
-		if visited.Contains(curNode) {
-			continue
-		}
-		visited.Add(curNode)
+		t.Order = append(t.Order, curNode)
 
-		nbrs, ok := g.GetNeighbors(curNode)
-		if !ok {
-			continue
-		}
-		for nbr := range nbrs {
+		graph.RangeNeighbors(g, curNode, func(nbr T, _ float64) bool {
+			if discovered.Contains(nbr) {
+				return true
+			}
+			discovered.Add(nbr)
+			t.Parent[nbr] = curNode
+			t.Depth[nbr] = t.Depth[curNode] + 1
 			q.Push(nbr)
-		}
+			return true
+		})
 	}
 
-	return visited.ToSlice()
+	return t
+}
+
+// ShortestPath finds a path with the fewest edges between two nodes, returning false if no path exists
+func ShortestPath[T comparable](g graph.ReadOnlyGraphOf[T], src T, tgt T) ([]T, bool) {
+	return BFSTraversal(g, src).PathTo(tgt)
+}
+
+// Path reconstructs the path from a source node to a target node using a map of each
+// node's predecessor, returning false if the target cannot be reached from the source
+func Path[T comparable](prev map[T]T, src T, tgt T) ([]T, bool) {
+	path := []T{tgt}
+	for cur := tgt; cur != src; {
+		p, ok := prev[cur]
+		if !ok {
+			return []T{}, false
+		}
+		path = append(path, p)
+		cur = p
+	}
+	// reverse the path so that it starts at the source
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
 }
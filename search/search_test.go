@@ -1,6 +1,8 @@
 package search
 
 import (
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -248,3 +250,383 @@ func TestBFS_DirectedGraph(t *testing.T) {
 		})
 	}
 }
+
+// assertValidTraversal checks that a traversal is internally consistent: the start node is visited
+// first and every other visited node has a parent that was visited before it and is one level shallower
+func assertValidTraversal(t *testing.T, g graph.ReadOnlyGraph, trav *Traversal, start n.Node) {
+	assert.Equal(t, start, trav.Order[0])
+	assert.Zero(t, trav.Depth[start])
+	assert.NotContains(t, trav.Parent, start)
+	assert.Len(t, trav.Depth, len(trav.Order))
+	assert.Len(t, trav.Parent, len(trav.Order)-1)
+
+	position := map[n.Node]int{}
+	for i, node := range trav.Order {
+		position[node] = i
+	}
+	for node, parent := range trav.Parent {
+		assert.Less(t, position[parent], position[node])
+		assert.Equal(t, trav.Depth[parent]+1, trav.Depth[node])
+		nbrs, _ := g.GetNeighbors(parent)
+		assert.Contains(t, nbrs, node)
+	}
+}
+
+func TestDFSTraversal(t *testing.T) {
+	tests := map[string]struct {
+		g             graph.ReadOnlyGraph
+		start         n.Node
+		expectedFound []n.Node
+	}{
+		"empty graph": {
+			g:             setupEmptyGraph(),
+			start:         "a",
+			expectedFound: []n.Node{},
+		},
+		"starting from non-existent node": {
+			g:             setupGraph(),
+			start:         "x",
+			expectedFound: []n.Node{},
+		},
+		"starting at root, undirected graph": {
+			g:             setupGraph(),
+			start:         "a",
+			expectedFound: []n.Node{"a", "b", "c", "d", "e", "z"},
+		},
+		"starting from node with self loop, undirected graph": {
+			g:             setupGraph(),
+			start:         "i",
+			expectedFound: []n.Node{"g", "h", "i"},
+		},
+		"starting from isolated node": {
+			g: func() *graph.Graph {
+				g := setupGraph()
+				g.AddNode("x")
+				return g
+			}(),
+			start:         "x",
+			expectedFound: []n.Node{"x"},
+		},
+		"starting at root, directed graph": {
+			g:             setupDirGraph(),
+			start:         "a",
+			expectedFound: []n.Node{"a", "b", "c", "d", "e", "z"},
+		},
+		"starting below root, directed graph": {
+			g:             setupDirGraph(),
+			start:         "b",
+			expectedFound: []n.Node{"b", "c", "d", "e"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			trav := DFSTraversal(test.g, test.start)
+			assert.ElementsMatch(t, test.expectedFound, trav.Order)
+			if len(test.expectedFound) == 0 {
+				assert.Empty(t, trav.Depth)
+				assert.Empty(t, trav.Parent)
+				return
+			}
+			assertValidTraversal(t, test.g, trav, test.start)
+		})
+	}
+}
+
+func TestDFSTraversalDepth(t *testing.T) {
+	t.Run("depth first search follows a path to its end", func(t *testing.T) {
+		g, _ := graph.NewDirGraph("path")
+		g.AddEdge("a", "b")
+		g.AddEdge("b", "c")
+		g.AddEdge("c", "d")
+		g.AddEdge("a", "d")
+		trav := DFSTraversal(g, "a")
+		// d is reached at depth 1 or 3 depending on which neighbor of a is searched first
+		if trav.Parent["d"] == "a" {
+			assert.Equal(t, 1, trav.Depth["d"])
+			return
+		}
+		assert.Equal(t, []n.Node{"a", "b", "c", "d"}, trav.Order)
+		assert.Equal(t, 3, trav.Depth["d"])
+	})
+}
+
+func TestBFSTraversal(t *testing.T) {
+	tests := map[string]struct {
+		g             graph.ReadOnlyGraph
+		start         n.Node
+		expectedDepth map[n.Node]int
+	}{
+		"empty graph": {
+			g:             setupEmptyGraph(),
+			start:         "a",
+			expectedDepth: map[n.Node]int{},
+		},
+		"starting from non-existent node": {
+			g:             setupGraph(),
+			start:         "x",
+			expectedDepth: map[n.Node]int{},
+		},
+		"starting at root, undirected graph": {
+			g:             setupGraph(),
+			start:         "a",
+			expectedDepth: map[n.Node]int{"a": 0, "b": 1, "z": 1, "c": 2, "d": 3, "e": 3},
+		},
+		"starting below root, undirected graph": {
+			g:             setupGraph(),
+			start:         "c",
+			expectedDepth: map[n.Node]int{"a": 2, "b": 1, "z": 3, "c": 0, "d": 1, "e": 1},
+		},
+		"starting from node with self loop, undirected graph": {
+			g:             setupGraph(),
+			start:         "i",
+			expectedDepth: map[n.Node]int{"g": 2, "h": 1, "i": 0},
+		},
+		"starting at root, directed graph": {
+			g:             setupDirGraph(),
+			start:         "a",
+			expectedDepth: map[n.Node]int{"a": 0, "b": 1, "z": 1, "c": 2, "d": 3, "e": 3},
+		},
+		"starting below root, directed graph": {
+			g:             setupDirGraph(),
+			start:         "c",
+			expectedDepth: map[n.Node]int{"c": 0, "d": 1, "e": 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			trav := BFSTraversal(test.g, test.start)
+			assert.Equal(t, test.expectedDepth, trav.Depth)
+			if len(test.expectedDepth) == 0 {
+				assert.Empty(t, trav.Order)
+				assert.Empty(t, trav.Parent)
+				return
+			}
+			assertValidTraversal(t, test.g, trav, test.start)
+			// breadth first search visits nodes in order of nondecreasing depth
+			for i := 1; i < len(trav.Order); i++ {
+				assert.LessOrEqual(t, trav.Depth[trav.Order[i-1]], trav.Depth[trav.Order[i]])
+			}
+		})
+	}
+}
+
+func TestTraversalPathTo(t *testing.T) {
+	tests := map[string]struct {
+		trav         *Traversal
+		tgt          n.Node
+		expectedPath []n.Node
+		expectedOk   bool
+	}{
+		"empty traversal": {
+			trav:         BFSTraversal(setupGraph(), "x"),
+			tgt:          "a",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+		"path to start": {
+			trav:         BFSTraversal(setupGraph(), "a"),
+			tgt:          "a",
+			expectedPath: []n.Node{"a"},
+			expectedOk:   true,
+		},
+		"path to visited node": {
+			trav:         BFSTraversal(setupGraph(), "a"),
+			tgt:          "d",
+			expectedPath: []n.Node{"a", "b", "c", "d"},
+			expectedOk:   true,
+		},
+		"path to unvisited node": {
+			trav:         BFSTraversal(setupGraph(), "a"),
+			tgt:          "g",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, ok := test.trav.PathTo(test.tgt)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	tests := map[string]struct {
+		g            graph.ReadOnlyGraph
+		src          n.Node
+		tgt          n.Node
+		expectedPath []n.Node
+		expectedOk   bool
+	}{
+		"non-existent source": {
+			g:            setupGraph(),
+			src:          "x",
+			tgt:          "a",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+		"source equals target": {
+			g:            setupGraph(),
+			src:          "a",
+			tgt:          "a",
+			expectedPath: []n.Node{"a"},
+			expectedOk:   true,
+		},
+		"path in undirected graph": {
+			g:            setupGraph(),
+			src:          "e",
+			tgt:          "z",
+			expectedPath: []n.Node{"e", "c", "b", "a", "z"},
+			expectedOk:   true,
+		},
+		"path against edge direction in directed graph": {
+			g:            setupDirGraph(),
+			src:          "e",
+			tgt:          "z",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+		"path with shortcut in directed graph": {
+			g: func() *graph.DirGraph {
+				g := setupDirGraph()
+				g.AddEdge("a", "e")
+				return g
+			}(),
+			src:          "a",
+			tgt:          "e",
+			expectedPath: []n.Node{"a", "e"},
+			expectedOk:   true,
+		},
+		"disconnected nodes": {
+			g:            setupGraph(),
+			src:          "a",
+			tgt:          "i",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, ok := ShortestPath(test.g, test.src, test.tgt)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	prev := map[n.Node]n.Node{"b": "a", "c": "b", "d": "b", "y": "x"}
+	tests := map[string]struct {
+		src          n.Node
+		tgt          n.Node
+		expectedPath []n.Node
+		expectedOk   bool
+	}{
+		"path to source": {
+			src:          "a",
+			tgt:          "a",
+			expectedPath: []n.Node{"a"},
+			expectedOk:   true,
+		},
+		"path to neighbor": {
+			src:          "a",
+			tgt:          "b",
+			expectedPath: []n.Node{"a", "b"},
+			expectedOk:   true,
+		},
+		"path to distant node": {
+			src:          "a",
+			tgt:          "d",
+			expectedPath: []n.Node{"a", "b", "d"},
+			expectedOk:   true,
+		},
+		"path from intermediate node": {
+			src:          "b",
+			tgt:          "c",
+			expectedPath: []n.Node{"b", "c"},
+			expectedOk:   true,
+		},
+		"unreachable target": {
+			src:          "a",
+			tgt:          "y",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+		"non-existent target": {
+			src:          "a",
+			tgt:          "z",
+			expectedPath: []n.Node{},
+			expectedOk:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, ok := Path(prev, test.src, test.tgt)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedPath, path)
+		})
+	}
+}
+
+// setupBenchGraphs creates a large random directed graph in both its map and compact forms
+func setupBenchGraphs(numNodes int, numEdges int) (*graph.DirGraph, *graph.CompactGraph) {
+	rng := rand.New(rand.NewSource(1))
+	dg, _ := graph.NewDirGraph("bench")
+	for i := 0; i < numNodes; i++ {
+		dg.AddNode(n.Node(strconv.Itoa(i)))
+	}
+	for i := 0; i < numEdges; i++ {
+		src := n.Node(strconv.Itoa(rng.Intn(numNodes)))
+		tgt := n.Node(strconv.Itoa(rng.Intn(numNodes)))
+		dg.AddEdge(src, tgt, 1+rng.Float64())
+	}
+	return dg, dg.Compact()
+}
+
+func benchmarkSearch(b *testing.B, search func(graph.ReadOnlyGraph, n.Node)) {
+	dg, cg := setupBenchGraphs(20000, 200000)
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			search(dg, "0")
+		}
+	})
+	b.Run("compact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			search(cg, "0")
+		}
+	})
+}
+
+func BenchmarkDFS(b *testing.B) {
+	benchmarkSearch(b, func(g graph.ReadOnlyGraph, node n.Node) { DFS(g, node) })
+}
+
+func BenchmarkBFS(b *testing.B) {
+	benchmarkSearch(b, func(g graph.ReadOnlyGraph, node n.Node) { BFS(g, node) })
+}
+
+func TestSearchGenericNodes(t *testing.T) {
+	g := graph.NewDirGraphOf[int64]("ints")
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(4, 3)
+	g.AddEdge(5, 1)
+
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4}, DFS(g, 1))
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4}, BFS(g, 1))
+
+	trav := BFSTraversal(g, 1)
+	assert.Equal(t, 2, trav.Depth[3])
+	path, ok := ShortestPath(g, 5, 3)
+	assert.True(t, ok)
+	assert.Len(t, path, 4)
+	_, ok = ShortestPath(g, 3, 1)
+	assert.False(t, ok)
+}
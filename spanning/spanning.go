@@ -0,0 +1,154 @@
+package spanning
+
+import (
+	"sort"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+	n "github.com/dkaslovsky/GoGraph/node"
+)
+
+type edge struct {
+	src n.Node
+	tgt n.Node
+	wgt float64
+}
+
+// Kruskal computes a minimum spanning forest of an undirected graph using Kruskal's algorithm,
+// returning the forest as a new graph along with its total weight
+func Kruskal(g *graph.Graph) (*graph.Graph, float64) {
+	return kruskal(g, false)
+}
+
+// MaxKruskal computes a maximum spanning forest of an undirected graph using Kruskal's algorithm,
+// returning the forest as a new graph along with its total weight
+func MaxKruskal(g *graph.Graph) (*graph.Graph, float64) {
+	return kruskal(g, true)
+}
+
+// Prim computes a minimum spanning forest of an undirected graph using Prim's algorithm,
+// returning the forest as a new graph along with its total weight
+func Prim(g *graph.Graph) (*graph.Graph, float64) {
+	return prim(g, false)
+}
+
+// MaxPrim computes a maximum spanning forest of an undirected graph using Prim's algorithm,
+// returning the forest as a new graph along with its total weight
+func MaxPrim(g *graph.Graph) (*graph.Graph, float64) {
+	return prim(g, true)
+}
+
+func kruskal(g *graph.Graph, maximum bool) (*graph.Graph, float64) {
+	forest, _ := graph.NewGraph(g.Name) // no need to check error since there are no readers
+	total := 0.0
+
+	edges := getEdges(g)
+	sort.SliceStable(edges, func(i, j int) bool {
+		if maximum {
+			return edges[i].wgt > edges[j].wgt
+		}
+		return edges[i].wgt < edges[j].wgt
+	})
+
+	for _, node := range g.GetNodes() {
+		forest.AddNode(node)
+	}
+
+	ds := n.NewDisjointSet()
+	for _, e := range edges {
+		// an edge between nodes that are already connected would create a cycle
+		if !ds.Union(e.src, e.tgt) {
+			continue
+		}
+		forest.AddEdge(e.src, e.tgt, e.wgt)
+		total += e.wgt
+	}
+
+	return forest, total
+}
+
+func prim(g *graph.Graph, maximum bool) (*graph.Graph, float64) {
+	forest, _ := graph.NewGraph(g.Name) // no need to check error since there are no readers
+	total := 0.0
+
+	// the priority queue pops the lowest priority so negate weights to find a maximum
+	priority := func(wgt float64) float64 {
+		if maximum {
+			return -wgt
+		}
+		return wgt
+	}
+
+	visited := n.NewSet()
+	// best holds the best known edge connecting each unvisited node to the tree
+	best := map[n.Node]edge{}
+
+	nodes := g.GetNodes()
+	sortNodes(nodes)
+	for _, node := range nodes {
+		forest.AddNode(node)
+	}
+	// grow a tree from each node not yet spanned so that every component is spanned
+	for _, root := range nodes {
+		if visited.Contains(root) {
+			continue
+		}
+
+		pq := n.NewPriorityQueue()
+		pq.Push(root, 0)
+		for pq.Len() > 0 {
+			curNode, _, _ := pq.Pop() // no need to check error since the queue cannot be empty here
+			// nodes are pushed each time a better edge is found so skip those already in the tree
+			if visited.Contains(curNode) {
+				continue
+			}
+			visited.Add(curNode)
+			if e, ok := best[curNode]; ok {
+				forest.AddEdge(e.src, e.tgt, e.wgt)
+				total += e.wgt
+			}
+
+			nbrs, ok := g.GetNeighbors(curNode)
+			if !ok {
+				continue
+			}
+			for nbr, wgt := range nbrs {
+				if visited.Contains(nbr) {
+					continue
+				}
+				if e, ok := best[nbr]; ok && priority(e.wgt) <= priority(wgt) {
+					continue
+				}
+				best[nbr] = edge{src: curNode, tgt: nbr, wgt: wgt}
+				pq.Push(nbr, priority(wgt))
+			}
+		}
+	}
+
+	return forest, total
+}
+
+// getEdges returns each edge of an undirected graph once, in sorted order, excluding self loops
+func getEdges(g *graph.Graph) []edge {
+	nodes := g.GetNodes()
+	sortNodes(nodes)
+
+	edges := []edge{}
+	for _, src := range nodes {
+		nbrs, _ := g.GetNeighbors(src)
+		tgts := []n.Node{}
+		for tgt := range nbrs {
+			if src < tgt {
+				tgts = append(tgts, tgt)
+			}
+		}
+		sortNodes(tgts)
+		for _, tgt := range tgts {
+			edges = append(edges, edge{src: src, tgt: tgt, wgt: nbrs[tgt]})
+		}
+	}
+	return edges
+}
+
+func sortNodes(nodes []n.Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+}
@@ -0,0 +1,92 @@
+package spanning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dkaslovsky/GoGraph/graph"
+)
+
+// float64EqualTol is the tolerance at which we consider float64s equal
+const float64EqualTol = 1e-9
+
+func setupGraph() *graph.Graph {
+	g, _ := graph.NewGraph("spanning")
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("b", "d", 5)
+	g.AddEdge("c", "d", 8)
+	g.AddEdge("d", "e", 3)
+	g.AddEdge("c", "e", 9)
+	g.AddEdge("e", "e", 0.5)
+	// second component
+	g.AddEdge("x", "y", 6)
+	g.AddEdge("y", "z", 7)
+	g.AddEdge("x", "z", 2.5)
+	// isolated node
+	g.AddNode("w")
+	return g
+}
+
+type spanningFunc func(*graph.Graph) (*graph.Graph, float64)
+
+func TestMinimumSpanningForest(t *testing.T) {
+	expectedEdges := []edge{
+		{src: "a", tgt: "c", wgt: 1},
+		{src: "b", tgt: "c", wgt: 2},
+		{src: "b", tgt: "d", wgt: 5},
+		{src: "d", tgt: "e", wgt: 3},
+		{src: "x", tgt: "z", wgt: 2.5},
+		{src: "x", tgt: "y", wgt: 6},
+	}
+	testSpanningForest(t, map[string]spanningFunc{"Kruskal": Kruskal, "Prim": Prim}, expectedEdges, 19.5)
+}
+
+func TestMaximumSpanningForest(t *testing.T) {
+	expectedEdges := []edge{
+		{src: "c", tgt: "e", wgt: 9},
+		{src: "c", tgt: "d", wgt: 8},
+		{src: "b", tgt: "d", wgt: 5},
+		{src: "a", tgt: "b", wgt: 4},
+		{src: "y", tgt: "z", wgt: 7},
+		{src: "x", tgt: "y", wgt: 6},
+	}
+	testSpanningForest(t, map[string]spanningFunc{"MaxKruskal": MaxKruskal, "MaxPrim": MaxPrim}, expectedEdges, 39)
+}
+
+func testSpanningForest(t *testing.T, funcs map[string]spanningFunc, expectedEdges []edge, expectedTotal float64) {
+	for name, f := range funcs {
+		t.Run(name+" empty graph", func(t *testing.T) {
+			g, _ := graph.NewGraph("empty")
+			forest, total := f(g)
+			assert.Equal(t, "empty", forest.Name)
+			assert.Empty(t, forest.GetNodes())
+			assert.Zero(t, total)
+		})
+		t.Run(name+" graph with multiple components", func(t *testing.T) {
+			g := setupGraph()
+			forest, total := f(g)
+			assert.Equal(t, g.Name, forest.Name)
+			assert.InEpsilon(t, expectedTotal, total, float64EqualTol)
+			assert.ElementsMatch(t, expectedEdges, getEdges(forest))
+			// every node is spanned, including isolated nodes
+			assert.ElementsMatch(t, g.GetNodes(), forest.GetNodes())
+		})
+	}
+}
+
+func TestGetEdges(t *testing.T) {
+	t.Run("each edge is returned once without self loops", func(t *testing.T) {
+		g, _ := graph.NewGraph("test")
+		g.AddEdge("b", "a", 1.5)
+		g.AddEdge("a", "c", 2)
+		g.AddEdge("c", "c", 3)
+		edges := getEdges(g)
+		assert.Equal(t, []edge{
+			{src: "a", tgt: "b", wgt: 1.5},
+			{src: "a", tgt: "c", wgt: 2},
+		}, edges)
+	})
+}